@@ -2,6 +2,10 @@ package iec104
 
 import (
 	"fmt"
+	"io"
+	"strings"
+
+	elements "github.com/wangxianzhuo/iec104/msg-elements"
 )
 
 type APCI struct {
@@ -14,14 +18,24 @@ type APCI struct {
 }
 
 func (apci APCI) ConvertBytes() []byte {
-	return []byte{
+	return apci.AppendTo(make([]byte, 0, 2+ApciLen))
+}
+
+// AppendTo 将编码结果追加到dst
+func (apci APCI) AppendTo(dst []byte) []byte {
+	return append(dst,
 		apci.Start,
 		byte(apci.ApduLen),
 		apci.Ctr1,
 		apci.Ctr2,
 		apci.Ctr3,
 		apci.Ctr4,
-	}
+	)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (apci APCI) WriteTo(w io.Writer) (int64, error) {
+	return elements.WriteTo(w, apci)
 }
 
 type IFrame struct {
@@ -44,6 +58,42 @@ type UFrame struct {
 	STARTDT_ACT bool //U帧，启动激活
 }
 
+// String 如 APCI[68 0E 00 00 02 00]
+func (apci APCI) String() string {
+	return fmt.Sprintf("APCI[% X]", apci.AppendTo(nil))
+}
+
+// String 如 I(N(S)=0 N(R)=1)
+func (f IFrame) String() string {
+	return fmt.Sprintf("I(N(S)=%d N(R)=%d)", f.Send, f.Recv)
+}
+
+// String 如 S(N(R)=1)
+func (f SFrame) String() string {
+	return fmt.Sprintf("S(N(R)=%d)", f.Recv)
+}
+
+// String 如 U(STARTDT_ACT)
+func (f UFrame) String() string {
+	var names []string
+	for _, n := range []struct {
+		set  bool
+		name string
+	}{
+		{f.STARTDT_ACT, "STARTDT_ACT"},
+		{f.STARTDT_CON, "STARTDT_CON"},
+		{f.STOPDT_ACT, "STOPDT_ACT"},
+		{f.STOPDT_CON, "STOPDT_CON"},
+		{f.TESTFR_ACT, "TESTFR_ACT"},
+		{f.TESTFR_CON, "TESTFR_CON"},
+	} {
+		if n.set {
+			names = append(names, n.name)
+		}
+	}
+	return "U(" + strings.Join(names, "|") + ")"
+}
+
 func ParseCtr(apci APCI) (byte, interface{}, error) {
 	var frameType byte
 	if apci.Ctr1&0x01 == 0 {
@@ -62,7 +112,7 @@ func ParseCtr(apci APCI) (byte, interface{}, error) {
 		t, f := parseUFrame(apci)
 		return t, f, nil
 	default:
-		return 0xFF, nil, fmt.Errorf("未知APCI帧类型: [%X]", frameType)
+		return 0xFF, nil, fmt.Errorf("%w[%X]", ErrUnknownFrameType, frameType)
 	}
 }
 
@@ -111,15 +161,15 @@ func NewAPCI(len int, ctr interface{}) (APCI, error) {
 
 	switch frame := ctr.(type) {
 	case IFrame:
-		apci.Ctr1 = byte(frame.Send) << 1
-		apci.Ctr2 = byte(frame.Send >> 8)
-		apci.Ctr3 = byte(frame.Recv) << 1
-		apci.Ctr4 = byte(frame.Recv >> 8)
+		apci.Ctr1 = byte(frame.Send << 1)
+		apci.Ctr2 = byte(frame.Send >> 7)
+		apci.Ctr3 = byte(frame.Recv << 1)
+		apci.Ctr4 = byte(frame.Recv >> 7)
 	case SFrame:
 		apci.Ctr1 = 1
 		apci.Ctr2 = 0
-		apci.Ctr3 = byte(frame.Recv) << 1
-		apci.Ctr4 = byte(frame.Recv >> 8)
+		apci.Ctr3 = byte(frame.Recv << 1)
+		apci.Ctr4 = byte(frame.Recv >> 7)
 	case UFrame:
 		var ctr1 byte
 		if frame.STARTDT_ACT {
@@ -145,7 +195,7 @@ func NewAPCI(len int, ctr interface{}) (APCI, error) {
 		apci.Ctr3 = 0
 		apci.Ctr4 = 0
 	default:
-		return APCI{}, fmt.Errorf("未知控制域类型[%T]", ctr)
+		return APCI{}, fmt.Errorf("%w[%T]", ErrUnknownFrameType, ctr)
 	}
 
 	return apci, nil
@@ -1,13 +1,16 @@
 package iec104
 
 import (
+	"errors"
 	"fmt"
+	"io"
 
 	elements "github.com/wangxianzhuo/iec104/msg-elements"
 )
 
 const (
-	ApciLen = 4
+	ApciLen    = 4
+	MaxApduLen = 253 // APDU长度域最大值
 )
 
 type APDU struct {
@@ -19,18 +22,24 @@ type APDU struct {
 	CtrFrame interface{}
 }
 
+// NewAPDU 由apci及asdu创建APDU，apci的长度域必须与asdu长度一致
+//
+// 一般使用 BuildAPDU，由控制域自动计算长度域。
 func NewAPDU(apci APCI, asdup *elements.ASDU) (APDU, error) {
 	var asduLen int
 	var asdu elements.ASDU
 	if asdup == nil {
 		asduLen = 0
 	} else {
-		asduLen = len(asdup.ConvertBytes())
+		asduLen = asdup.Size()
 		asdu = *asdup
 	}
+	if apci.ApduLen != ApciLen+asduLen {
+		return APDU{}, fmt.Errorf("%w: 长度域[%d]与ASDU长度[%d]不符", ErrLengthMismatch, apci.ApduLen, asduLen)
+	}
 	t, f, err := ParseCtr(apci)
 	if err != nil {
-		return APDU{}, fmt.Errorf("解析控制域异常: %v", err)
+		return APDU{}, fmt.Errorf("解析控制域异常: %w", err)
 	}
 	return APDU{
 		APCI:     apci,
@@ -42,13 +51,58 @@ func NewAPDU(apci APCI, asdup *elements.ASDU) (APDU, error) {
 	}, nil
 }
 
+// BuildAPDU 由控制域ctr（IFrame、SFrame或UFrame）及asdu创建APDU，自动计算长度域
+//
+// I帧必须带有asdu，S帧及U帧不能带有asdu，APDU长度超过253字节时返回 ErrTooLong。
+func BuildAPDU(ctr interface{}, asdu *elements.ASDU) (APDU, error) {
+	length := ApciLen
+	if asdu != nil {
+		length += asdu.Size()
+	}
+	if length > MaxApduLen {
+		return APDU{}, fmt.Errorf("%w: %d", ErrTooLong, length)
+	}
+	switch ctr.(type) {
+	case IFrame:
+		if asdu == nil {
+			return APDU{}, fmt.Errorf("%w: I帧缺少ASDU", ErrLengthMismatch)
+		}
+	case SFrame, UFrame:
+		if asdu != nil {
+			return APDU{}, fmt.Errorf("%w: %T不能带有ASDU", ErrLengthMismatch, ctr)
+		}
+	}
+	apci, err := NewAPCI(length, ctr)
+	if err != nil {
+		return APDU{}, err
+	}
+	return NewAPDU(apci, asdu)
+}
+
+// ParseAPDU 按104规约标准参数解析APDU
 func ParseAPDU(input []byte) (APDU, error) {
-	if input == nil || len(input) < 6 {
-		return APDU{}, fmt.Errorf("APDU报文[%X]非法", input)
+	return ParseAPDUWithParams(input, elements.DefaultParams)
+}
+
+// ParseAPDUWithParams 按指定参数解析APDU
+//
+// 严格模式下长度域必须在4到253之间且与实际长度一致，I帧必须带有ASDU，S帧及U帧不能带有ASDU；
+// 宽松模式下以实际长度为准。
+func ParseAPDUWithParams(input []byte, p elements.Params) (APDU, error) {
+	if len(input) < 2+ApciLen {
+		return APDU{}, &ParseError{Offset: len(input), Data: input, Err: ErrTruncated}
 	}
 	start := input[0]
 	if start != 0x68 {
-		return APDU{}, fmt.Errorf("APDU报文[%X]不是完整报文，找不到启动字符68H", input)
+		return APDU{}, &ParseError{Offset: 0, Data: input, Err: ErrInvalidStartByte}
+	}
+	if !p.Lenient {
+		if input[1] < ApciLen || input[1] > MaxApduLen {
+			return APDU{}, &ParseError{Offset: 1, Data: input, Err: ErrInvalidLength}
+		}
+		if int(input[1]) != len(input)-2 {
+			return APDU{}, &ParseError{Offset: 1, Data: input, Err: ErrLengthMismatch}
+		}
 	}
 
 	var apci APCI
@@ -61,21 +115,27 @@ func ParseAPDU(input []byte) (APDU, error) {
 
 	fType, ctrFrame, err := ParseCtr(apci)
 	if err != nil {
-		return APDU{}, fmt.Errorf("APDU报文[%X]解析控制域异常: %v", input, err)
+		return APDU{}, &ParseError{Offset: 2, Data: input, Err: err}
 	}
 
 	var asdu elements.ASDU
 	var asduLen int
 
-	if len(input[6:len(input)]) < 1 {
+	if len(input[6:]) < 1 {
+		if fType == 0 && !p.Lenient {
+			return APDU{}, &ParseError{Offset: len(input), Data: input, Err: ErrTruncated}
+		}
 		asdu = elements.ASDU{}
 		asduLen = 0
 	} else {
-		asdu, err = elements.ParseASDU(input[6:len(input)])
+		if fType != 0 && !p.Lenient {
+			return APDU{}, &ParseError{Offset: 6, Data: input, Err: ErrLengthMismatch}
+		}
+		asdu, err = elements.ParseASDUWithParams(input[6:], p)
 		if err != nil {
-			return APDU{}, fmt.Errorf("APDU报文[%X]解析ASDU域[%X]异常: %v", input, input[6:len(input)], err)
+			return APDU{}, shiftParseError(err, input, 6)
 		}
-		asduLen = len(input[6:len(input)])
+		asduLen = len(input[6:])
 	}
 
 	return APDU{
@@ -88,14 +148,48 @@ func ParseAPDU(input []byte) (APDU, error) {
 	}, nil
 }
 
+// ReadFrame 从字节流中读取一个完整的APDU，不解析其内容
+func ReadFrame(r io.Reader) ([]byte, error) {
+	head := make([]byte, 2)
+	_, err := io.ReadFull(r, head)
+	if err != nil {
+		return nil, err
+	}
+	if head[0] != 0x68 {
+		return nil, &ParseError{Offset: 0, Data: head, Err: ErrInvalidStartByte}
+	}
+	frame := make([]byte, 2+int(head[1]))
+	copy(frame, head)
+	_, err = io.ReadFull(r, frame[2:])
+	if err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
 func (apdu APDU) ConvertBytes() []byte {
-	var asdu []byte
+	return apdu.AppendTo(make([]byte, 0, 2+ApciLen+apdu.ASDULen))
+}
+
+// AppendTo 将编码结果追加到dst，dst容量足够时不分配内存
+func (apdu APDU) AppendTo(dst []byte) []byte {
+	dst = apdu.APCI.AppendTo(dst)
 	if apdu.ASDULen < 1 {
-		asdu = []byte{}
-	} else {
-		asdu = apdu.ASDU.ConvertBytes()
+		return dst
+	}
+	return apdu.ASDU.AppendTo(dst)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo，使用缓冲池避免内存分配
+func (apdu APDU) WriteTo(w io.Writer) (int64, error) {
+	return elements.WriteTo(w, apdu)
+}
+
+// shiftParseError 将ASDU解析异常的偏移转换为相对于APDU的偏移
+func shiftParseError(err error, input []byte, offset int) error {
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		return &ParseError{Offset: offset, Data: input, Err: err}
 	}
-	return append(apdu.APCI.ConvertBytes(),
-		asdu...,
-	)
+	return &ParseError{Offset: pe.Offset + offset, Data: input, Err: pe.Err}
 }
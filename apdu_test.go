@@ -1,9 +1,16 @@
 package iec104
 
 import (
+	"bytes"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
+
+	elements "github.com/wangxianzhuo/iec104/msg-elements"
 )
 
 func Test_parse(t *testing.T) {
@@ -15,6 +22,234 @@ func Test_parse(t *testing.T) {
 	fmt.Println(apdu)
 }
 
+func Test_Describe(t *testing.T) {
+	ins, _ := hex.DecodeString("680E00000200" + "2E01060001000500" + "0082")
+	apdu, err := ParseAPDU(ins)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, want := apdu.String(), "I(N(S)=0 N(R)=1) C_DC_NA_1(46) SQ=0 数目[1] act(6) 公共地址[1]: IOA[5] DCO=合 选择 QU[0]"; s != want {
+		t.Fatalf("apdu描述[%s]应为[%s]", s, want)
+	}
+	desc := Describe(apdu)
+	for _, line := range []string{"    TypeId: C_DC_NA_1 (46)\n", "    CauseTx: act(6)\n", "    IOA: 5\n", "      DCO: 合 选择 QU[0]\n"} {
+		if !strings.Contains(desc, line) {
+			t.Fatalf("Describe结果缺少[%q]:\n%s", line, desc)
+		}
+	}
+
+	u, _ := ParseAPDU([]byte{0x68, 0x04, 0x07, 0x00, 0x00, 0x00})
+	if s := u.String(); s != "U(STARTDT_ACT)" {
+		t.Fatalf("U帧描述[%s]错误", s)
+	}
+}
+
+func Test_JSON(t *testing.T) {
+	for _, s := range []string{
+		"6832000000000D050300010005400026365F3C00094000C1CA114000064000075E8D3F000240009D68273C000440008D92134000",
+		"680E0200040064010600010000000014",
+		"680401000600",
+		"680443000000",
+	} {
+		ins, _ := hex.DecodeString(s)
+		apdu, err := ParseAPDUWithParams(ins, elements.DefaultParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := json.Marshal(apdu)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded APDU
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatalf("JSON[%s]解析异常: %v", b, err)
+		}
+		if got := decoded.ConvertBytes(); !bytes.Equal(got, ins) {
+			t.Fatalf("JSON[%s]还原的报文[%X]应为[%X]", b, got, ins)
+		}
+	}
+
+	// 宽松模式下解析的I帧可能不带ASDU
+	lenient := elements.DefaultParams
+	lenient.Lenient = true
+	ins, _ := hex.DecodeString("680402000600")
+	apdu, err := ParseAPDUWithParams(ins, lenient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(apdu)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded APDU
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("JSON[%s]解析异常: %v", b, err)
+	}
+	if got := decoded.ConvertBytes(); !bytes.Equal(got, ins) {
+		t.Fatalf("JSON[%s]还原的报文[%X]应为[%X]", b, got, ins)
+	}
+}
+
+func Test_parseError(t *testing.T) {
+	cases := []struct {
+		input  string
+		err    error
+		offset int
+	}{
+		{"6904070000", ErrTruncated, 5},
+		{"690407000000", ErrInvalidStartByte, 0},
+		{"680E000000007F010600010000000014", ErrUnknownTypeID, 6},
+		{"680F000000006401060001000000001400", ErrLengthMismatch, 16},
+		{"68FF00000000", ErrInvalidLength, 1},
+		{"680D00000000640106000100000000", ErrTruncated, 15},
+		{"680E000000006401060001000000", ErrLengthMismatch, 1},
+		{"680400000000", ErrTruncated, 6},
+		{"680A01000000640106000100", ErrLengthMismatch, 6},
+	}
+	for _, c := range cases {
+		input, _ := hex.DecodeString(c.input)
+		_, err := ParseAPDU(input)
+		if !errors.Is(err, c.err) {
+			t.Fatalf("报文[%s]的解析异常[%v]应为[%v]", c.input, err, c.err)
+		}
+		var pe *ParseError
+		if !errors.As(err, &pe) || pe.Offset != c.offset {
+			t.Fatalf("报文[%s]的解析异常[%v]偏移应为[%d]", c.input, err, c.offset)
+		}
+	}
+}
+
+func Test_BuildAPDU(t *testing.T) {
+	asdu := elements.NewASDUC_IC_NA_1(elements.COT_ACT, 1, elements.QOI_GLOBAL_CALL)
+	apdu, err := BuildAPDU(IFrame{Send: 2, Recv: 3}, &asdu)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprintf("%X", apdu.ConvertBytes()); got != "680E0400060064010600010000000014" {
+		t.Fatalf("总召唤报文[%s]错误", got)
+	}
+	if apdu.Len != 14 || apdu.APCI.ApduLen != 14 {
+		t.Fatalf("长度域[%d %d]应为14", apdu.Len, apdu.APCI.ApduLen)
+	}
+
+	apdu, err = BuildAPDU(UFrame{STARTDT_ACT: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprintf("%X", apdu.ConvertBytes()); got != "680407000000" {
+		t.Fatalf("启动帧报文[%s]错误", got)
+	}
+
+	if _, err := BuildAPDU(IFrame{}, nil); !errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("I帧缺少ASDU的异常[%v]应为[%v]", err, ErrLengthMismatch)
+	}
+	if _, err := BuildAPDU(SFrame{}, &asdu); !errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("S帧带有ASDU的异常[%v]应为[%v]", err, ErrLengthMismatch)
+	}
+
+	var body elements.MessageElement_13_SQ_0
+	for i := 0; i < 31; i++ {
+		body = append(body, elements.MessageElement_13_SQ_0_Ele{Address: uint32(i)})
+	}
+	long := elements.ASDU{
+		DUI:         elements.DefaultParams.Apply(elements.DUI{TypeIdentification: elements.M_ME_NC_1, VariableStructureQualifier: 31}),
+		MessageBody: body,
+	}
+	if _, err := BuildAPDU(IFrame{}, &long); !errors.Is(err, ErrTooLong) {
+		t.Fatalf("超长APDU的异常[%v]应为[%v]", err, ErrTooLong)
+	}
+	long.MessageBody = body[:30]
+	long.DUI.VariableStructureQualifier = 30
+	if _, err := BuildAPDU(IFrame{}, &long); err != nil {
+		t.Fatal(err)
+	}
+
+	apci, _ := NewAPCI(ApciLen, IFrame{})
+	if _, err := NewAPDU(apci, &asdu); !errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("长度域错误的异常[%v]应为[%v]", err, ErrLengthMismatch)
+	}
+}
+
+// benchmarkAPDU 30个短浮点数测量值的I帧
+func benchmarkAPDU(tb testing.TB) APDU {
+	var body elements.MessageElement_13_SQ_0
+	for i := 0; i < 30; i++ {
+		body = append(body, elements.MessageElement_13_SQ_0_Ele{
+			Address: uint32(0x4001 + i),
+			Core:    elements.MessageElementCore_13{Value: float32(i) * 1.5},
+		})
+	}
+	asdu := elements.ASDU{
+		DUI: elements.DUI{
+			TypeIdentification:         elements.M_ME_NC_1,
+			VariableStructureQualifier: byte(len(body)),
+			COT:                        elements.COT{Cause: elements.COT_INTRGEN},
+			CauseExtEnable:             true,
+			PublicAddressLow:           1,
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: body,
+	}
+	apdu, err := BuildAPDU(IFrame{Send: 1}, &asdu)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return apdu
+}
+
+func Test_appendToAllocs(t *testing.T) {
+	apdu := benchmarkAPDU(t)
+	buf := make([]byte, 0, 2+MaxApduLen)
+	// AllocsPerRun 对平均值向下取整，以单次运行统计100次调用的总分配次数
+	allocs := testing.AllocsPerRun(1, func() {
+		for i := 0; i < 100; i++ {
+			buf = apdu.AppendTo(buf[:0])
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("AppendTo 100次分配内存%v次", allocs)
+	}
+	// 竞态检测下 sync.Pool 随机丢弃缓冲区，不检查WriteTo的内存分配
+	if !raceEnabled {
+		allocs = testing.AllocsPerRun(1, func() {
+			for i := 0; i < 100; i++ {
+				apdu.WriteTo(io.Discard)
+			}
+		})
+		if allocs != 0 {
+			t.Fatalf("WriteTo 100次分配内存%v次", allocs)
+		}
+	}
+	if string(buf) != string(apdu.ConvertBytes()) {
+		t.Fatalf("AppendTo结果[%X]与ConvertBytes结果[%X]不一致", buf, apdu.ConvertBytes())
+	}
+}
+
+func Benchmark_APDUConvertBytes(b *testing.B) {
+	apdu := benchmarkAPDU(b)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		apdu.ConvertBytes()
+	}
+}
+
+func Benchmark_APDUAppendTo(b *testing.B) {
+	apdu := benchmarkAPDU(b)
+	buf := make([]byte, 0, 2+MaxApduLen)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = apdu.AppendTo(buf[:0])
+	}
+}
+
+func Benchmark_APDUWriteTo(b *testing.B) {
+	apdu := benchmarkAPDU(b)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		apdu.WriteTo(io.Discard)
+	}
+}
+
 // func Test_ParseAPDUUFrame(t *testing.T) {
 // 	ins, err := hex.DecodeString("680407000000")
 // 	if err != nil {
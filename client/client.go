@@ -1,171 +1,336 @@
 package client
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/wangxianzhuo/iec104/msg-elements"
 
 	"github.com/wangxianzhuo/iec104"
-
-	"github.com/sirupsen/logrus"
+	"github.com/wangxianzhuo/iec104/logger"
 )
 
-var (
-	connectDeadline time.Duration
+const (
+	defaultTestInterval    = 20 * time.Second
+	defaultConnectDeadline = 5 * time.Minute
+	defaultTimeout         = 15 * time.Second
 )
 
-// SetConnectDeadLine 修改默认连接超时时间
-func SetConnectDeadLine(d time.Duration) {
-	connectDeadline = d
+// Config 客户端配置
+type Config struct {
+	Address         string          // 从站地址，如 127.0.0.1:2404
+	TestInterval    time.Duration   // 连接测试周期，默认20秒
+	ConnectDeadline time.Duration   // 连接无数据超时时间，默认5分钟
+	Timeout         time.Duration   // U帧确认超时时间（t1），默认15秒
+	CommonAddress   uint16          // 应用服务数据单元公共地址，默认为1
+	Params          elements.Params // ASDU编解码参数，默认为104规约标准参数
+
+	Output            chan<- map[string]float32 // 数据输出，为nil时丢弃数据
+	Events            chan<- Event              // 事件输出，如继电保护设备事件、成组单点信息，为nil时丢弃事件
+	Location          *time.Location            // 时标所在时区，默认为time.Local
+	Log               logger.Logger             // 日志，为nil时不输出日志
+	Points            *PointMap                 // 点表，为nil时以十六进制信息对象地址作为标签
+	Schedules         []Schedule                // 周期召唤计划
+	OnScheduleTimeout func(Schedule)            // 召唤计划等待激活终止超时回调
+
+	// TimeTaggedCommands 以带时标CP56Time2a的类型（C_SC_TA_1至C_BO_TA_1）发送命令，时标为发送时的当前时间
+	TimeTaggedCommands bool
+
+	// 收到从站初始化结束（M_EI_NA_1）后自动执行的操作，先时钟同步再总召唤
+	ReinterrogateOnInit bool // 自动总召唤
+	ClockSyncOnInit     bool // 自动时钟同步
+}
+
+func (cfg Config) withDefaults() Config {
+	if cfg.TestInterval <= 0 {
+		cfg.TestInterval = defaultTestInterval
+	}
+	if cfg.ConnectDeadline <= 0 {
+		cfg.ConnectDeadline = defaultConnectDeadline
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.CommonAddress == 0 {
+		cfg.CommonAddress = 0x01
+	}
+	if cfg.Location == nil {
+		cfg.Location = time.Local
+	}
+	cfg.Log = logger.OrNop(cfg.Log)
+	return cfg
 }
 
 // Client IEC104客户端
 type Client struct {
+	cfg      Config
 	conn     net.Conn
 	dataChan chan iec104.APDU
 	ctrChan  chan iec104.APDU
-	outChan  chan map[string]float32
-	ctx      context.Context
-	cancel   context.CancelFunc
-	Log      *logrus.Entry
-	mux      *sync.Mutex
+	conChan  chan iec104.APDU // U帧确认
+	Log      logger.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mux     sync.Mutex // 保护running、closed及err
+	running bool
+	closed  bool
+	err     error
+
+	closeOnce sync.Once
+	wmux      sync.Mutex // 写锁，同时保护wbuf
+	wbuf      []byte
+	umux      sync.Mutex // U帧命令锁，同一时刻只有一个U帧命令等待确认
+	seq       sequence
+	sched     *scheduler
+	cmds      *commands
+	tsc       atomic.Uint32 // 带时标测试命令的测试顺序计数器
+	xfer      files
+}
+
+// sequence I帧发送及接收序号
+type sequence struct {
+	mux sync.Mutex
+	vs  int16 // 发送序号
+	vr  int16 // 接收序号
 }
 
-// New ...
-func New(address string, outChan chan map[string]float32, logger *logrus.Entry) (Client, context.CancelFunc, error) {
-	if logger == nil {
-		panic("logrus.Entry is nil")
+// received 记录收到的I帧，返回更新后的接收序号
+func (s *sequence) received(f iec104.IFrame) int16 {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.vr = (f.Send + 1) & 0x7FFF
+	return s.vr
+}
+
+// Dial 连接从站，ctx仅用于控制建立连接的过程
+func Dial(ctx context.Context, cfg Config) (*Client, error) {
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("从站地址为空")
 	}
-	conn, err := net.Dial("tcp", address)
-	if err != nil {
-		return Client{}, nil, fmt.Errorf("创建TCP连接异常: %v", err)
+	cfg = cfg.withDefaults()
+	if err := cfg.Params.Validate(); err != nil {
+		return nil, err
+	}
+	for _, s := range cfg.Schedules {
+		if err := s.validate(); err != nil {
+			return nil, fmt.Errorf("召唤计划配置异常: %v", err)
+		}
 	}
 
-	connectDeadline = 5 * time.Minute
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", cfg.Address)
+	if err != nil {
+		return nil, fmt.Errorf("创建TCP连接异常: %v", err)
+	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	return Client{
+	c := &Client{
+		cfg:      cfg,
 		conn:     conn,
 		dataChan: make(chan iec104.APDU),
 		ctrChan:  make(chan iec104.APDU),
-		outChan:  outChan,
-		ctx:      ctx,
-		cancel:   cancel,
-		Log:      logger,
-		mux:      new(sync.Mutex),
-	}, cancel, nil
+		conChan:  make(chan iec104.APDU, 1),
+		Log:      cfg.Log,
+		sched:    newScheduler(cfg.Schedules),
+		cmds:     newCommands(),
+	}
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	return c, nil
 }
 
-// Close ...
-func (c Client) Close() {
-	c.cancel()
+// Run 启动通讯并阻塞，直到通讯异常、ctx结束或调用Close
+//
+// 返回值为导致通讯结束的异常；由Close结束时返回nil，由ctx结束时返回ctx.Err()。
+// Run只能调用一次，返回时连接已关闭。
+func (c *Client) Run(ctx context.Context) error {
+	c.mux.Lock()
+	if c.closed {
+		c.mux.Unlock()
+		return ErrClosed
+	}
+	if c.running {
+		c.mux.Unlock()
+		return fmt.Errorf("IEC104客户端已运行")
+	}
+	c.running = true
+	c.mux.Unlock()
+
+	c.Log.Infof("IEC104客户端通讯启动")
+	stop := context.AfterFunc(ctx, func() {
+		c.fail(ctx.Err())
+	})
+	defer stop()
+
+	c.spawn(c.read)
+	c.spawn(c.uFrameResp)
+	c.spawn(func() error {
+		err := c.init()
+		if err != nil {
+			return err
+		}
+		err = c.totalCall()
+		if err != nil {
+			return err
+		}
+		c.spawn(c.connectionTest)
+		c.spawn(c.receive)
+		if len(c.cfg.Schedules) > 0 {
+			c.spawn(c.schedule)
+		}
+		return nil
+	})
+
+	<-c.ctx.Done()
+	// 解除socket读阻塞
+	c.conn.SetReadDeadline(time.Now())
+	c.wg.Wait()
 	c.conn.Close()
-	c.Log.Info("IEC104客户端停止")
+	c.Log.Infof("IEC104客户端通讯结束")
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.closed {
+		return nil
+	}
+	return c.err
 }
 
-// Start 启动
-func (c Client) Start(testInterval time.Duration) {
-	c.Log.Info("IEC104客户端通讯启动")
-	go c.read()
-	c.init()
-	go c.connectionTest(testInterval)
-	go c.uFrameResp()
-	err := c.totalCall()
-	if err != nil {
-		c.Log.Panic(err)
+// Close 发送停止帧并关闭连接，等待所有协程退出，可重复调用
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		c.mux.Lock()
+		c.closed = true
+		running := c.running
+		c.mux.Unlock()
+
+		if running && c.ctx.Err() == nil {
+			err := c.stop()
+			if err != nil {
+				c.Log.Warnf("发送停止帧异常: %v", err)
+			}
+		}
+		c.cancel()
+		c.conn.SetReadDeadline(time.Now())
+		c.wg.Wait()
+		c.conn.Close()
+		c.Log.Infof("IEC104客户端停止")
+	})
+	return nil
+}
+
+// spawn 启动协程，协程返回异常时结束通讯
+func (c *Client) spawn(f func() error) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		err := f()
+		if err != nil {
+			c.fail(err)
+		}
+	}()
+}
+
+// fail 记录第一个导致通讯结束的异常并结束通讯
+func (c *Client) fail(err error) {
+	c.mux.Lock()
+	if c.err == nil && c.ctx.Err() == nil {
+		c.err = err
 	}
-	c.receive()
+	c.mux.Unlock()
+	c.cancel()
 }
 
-func (c Client) connectionTest(testInterval time.Duration) {
-	defer c.cancel()
-	c.Log.Infof("IEC104连接测试启动，每%v执行一次连接测试", testInterval)
-	ticker := time.NewTicker(testInterval)
-LOOP:
+func (c *Client) connectionTest() error {
+	c.Log.Infof("IEC104连接测试启动，每%v执行一次连接测试", c.cfg.TestInterval)
+	ticker := time.NewTicker(c.cfg.TestInterval)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ticker.C:
 			err := c.test()
 			if err != nil {
-				c.Log.Errorf("连接测试失败: %v", err)
-				c.Log.Errorf("尝试重新连接")
-				for i := 0; i < 5; i++ {
-					err := c.reconnect()
-					if err != nil {
-						c.Log.Errorf("第%d次重连失败: %v", i, err)
-					} else {
-						continue LOOP
-					}
-				}
-				c.Log.Errorf("结束通讯")
-				c.cancel()
-				return
+				return fmt.Errorf("连接测试失败: %w", err)
 			}
 		case <-c.ctx.Done():
-			c.Log.Info("IEC104连接测试停止")
+			c.Log.Infof("IEC104连接测试停止")
+			return nil
 		}
 	}
-
 }
 
-func (c Client) receive() {
-	defer c.cancel()
-	c.Log.Info("数据接收线程启动")
-LOOP:
+func (c *Client) receive() error {
+	c.Log.Infof("数据接收线程启动")
 	for {
 		select {
 		case resp := <-c.dataChan:
 			switch f := resp.CtrFrame.(type) {
 			case iec104.IFrame:
 				// 处理I帧
-				if resp.ASDU.DUI.TypeIdentification != elements.C_IC_NA_1 || resp.ASDU.DUI.Cause != elements.COT_ACTCON {
-					// 非总召唤确认
-					// 这里可能会有异常
-					c.Log.Debugf("准备解析APDU[%v]浮点数", resp)
-					data, err := handleData(resp)
-					if err != nil {
-						c.Log.Errorf("解析处理I帧异常: %v", err)
-						continue LOOP
-					}
-
-					c.outChan <- data
-					c.Log.Debugf("获得数据: %v", data)
+				if !c.sched.confirm(resp.ASDU) && !c.cmds.confirm(resp.ASDU) && !c.xfer.deliver(c.ctx, resp.ASDU) {
+					// 非命令的确认或终止，也不属于文件传输
+					c.dispatch(resp)
 				}
 
 				// 响应S帧
 				sFrame := iec104.SFrame{
-					Recv: f.Send + 1,
+					Recv: c.seq.received(f),
 				}
-				apci, _ := iec104.NewAPCI(iec104.ApciLen, sFrame)
-				resp, _ := iec104.NewAPDU(apci, nil)
-				_, err := c.conn.Write(resp.ConvertBytes())
+				resp, _ := iec104.BuildAPDU(sFrame, nil)
+				err := c.write(resp)
 				if err != nil {
-					c.Log.Errorf("响应S帧[%v]异常: %v", resp, err)
-					continue LOOP
+					return fmt.Errorf("响应S帧[%v]异常: %w", resp, err)
 				}
-				c.Log.Debugf("响应S帧[%X]", resp.ConvertBytes())
 			case iec104.SFrame:
 				c.Log.Infof("接收S帧: [%X]", resp.ConvertBytes())
 			default:
 				c.Log.Warnf("未知类型APDU[%X]", resp.ConvertBytes())
 			}
 		case <-c.ctx.Done():
-			c.Log.Info("数据接收线程停止")
+			c.Log.Infof("数据接收线程停止")
+			return nil
 		}
 	}
 }
 
-func (c Client) init() error {
-	c.Log.Info("IEC104客户端通讯初始化")
-	defer c.Log.Info("IEC104客户端通讯初始化结束")
-	// err := c.stop()
-	// if err != nil {
-	// 	return err
-	// }
+// dispatch 将I帧转换为数据或事件输出
+func (c *Client) dispatch(apdu iec104.APDU) {
+	c.Log.Debugf("准备解析APDU[%v]", apdu)
+	if events := toEvents(apdu.ASDU, c.cfg.Points, c.cfg.Location); events != nil {
+		c.emit(events)
+		if apdu.ASDU.DUI.TypeIdentification == elements.M_EI_NA_1 {
+			c.restarted()
+		}
+		return
+	}
+	data, err := handleData(apdu, c.cfg.Points)
+	if err != nil {
+		c.Log.Errorf("解析处理I帧异常: %v", err)
+		return
+	}
+	c.output(data)
+}
+
+func (c *Client) output(data map[string]float32) {
+	if c.cfg.Output == nil {
+		return
+	}
+	select {
+	case c.cfg.Output <- data:
+		c.Log.Debugf("获得数据: %v", data)
+	case <-c.ctx.Done():
+	}
+}
+
+func (c *Client) init() error {
+	c.Log.Infof("IEC104客户端通讯初始化")
+	defer c.Log.Infof("IEC104客户端通讯初始化结束")
 	err := c.start()
 	if err != nil {
 		return err
@@ -173,23 +338,18 @@ func (c Client) init() error {
 	return nil
 }
 
-func (c Client) stop() error {
+func (c *Client) stop() error {
 	uFrame := iec104.UFrame{
 		STOPDT_ACT: true,
 	}
-	apci, err := iec104.NewAPCI(iec104.ApciLen, uFrame)
-	if err != nil {
-		return fmt.Errorf("停止帧控制域创建异常: %v", err)
-	}
-
-	apdu, err := iec104.NewAPDU(apci, nil)
+	apdu, err := iec104.BuildAPDU(uFrame, nil)
 	if err != nil {
 		return fmt.Errorf("停止帧创建异常: %v", err)
 	}
 
 	resp, err := c.writeUFrame(apdu)
 	if err != nil {
-		return fmt.Errorf("停止帧发送异常: %v", err)
+		return fmt.Errorf("停止帧发送异常: %w", err)
 	}
 	if !resp.CtrFrame.(iec104.UFrame).STOPDT_CON {
 		return fmt.Errorf("停止帧响应[%v]的停止确认没有置位", resp)
@@ -198,23 +358,18 @@ func (c Client) stop() error {
 	return nil
 }
 
-func (c Client) start() error {
+func (c *Client) start() error {
 	uFrame := iec104.UFrame{
 		STARTDT_ACT: true,
 	}
-	apci, err := iec104.NewAPCI(iec104.ApciLen, uFrame)
-	if err != nil {
-		return fmt.Errorf("启动帧控制域创建异常: %v", err)
-	}
-
-	apdu, err := iec104.NewAPDU(apci, nil)
+	apdu, err := iec104.BuildAPDU(uFrame, nil)
 	if err != nil {
 		return fmt.Errorf("启动帧创建异常: %v", err)
 	}
 
 	resp, err := c.writeUFrame(apdu)
 	if err != nil {
-		return fmt.Errorf("启动帧发送异常: %v", err)
+		return fmt.Errorf("启动帧发送异常: %w", err)
 	}
 	if !resp.CtrFrame.(iec104.UFrame).STARTDT_CON {
 		return fmt.Errorf("启动帧响应[%v]的启动确认没有置位", resp)
@@ -223,23 +378,18 @@ func (c Client) start() error {
 	return nil
 }
 
-func (c Client) test() error {
+func (c *Client) test() error {
 	uFrame := iec104.UFrame{
 		TESTFR_ACT: true,
 	}
-	apci, err := iec104.NewAPCI(iec104.ApciLen, uFrame)
-	if err != nil {
-		return fmt.Errorf("测试帧控制域创建异常: %v", err)
-	}
-
-	apdu, err := iec104.NewAPDU(apci, nil)
+	apdu, err := iec104.BuildAPDU(uFrame, nil)
 	if err != nil {
 		return fmt.Errorf("测试帧创建异常: %v", err)
 	}
 
 	resp, err := c.writeUFrame(apdu)
 	if err != nil {
-		return fmt.Errorf("测试帧发送异常: %v", err)
+		return fmt.Errorf("测试帧发送异常: %w", err)
 	}
 	if !resp.CtrFrame.(iec104.UFrame).TESTFR_CON {
 		return fmt.Errorf("测试帧响应[%v]的测试确认没有置位", resp)
@@ -248,212 +398,199 @@ func (c Client) test() error {
 	return nil
 }
 
-func (c Client) totalCall() error {
+func (c *Client) totalCall() error {
+	asdu := elements.NewASDUC_IC_NA_1(elements.COT_ACT, c.cfg.CommonAddress, byte(elements.QOI_GLOBAL_CALL))
+	err := c.sendI(asdu)
+	if err != nil {
+		return fmt.Errorf("总召唤发送异常: %w", err)
+	}
+	return nil
+}
+
+// restarted 从站初始化结束后按配置执行时钟同步及总召唤，在新协程中执行以免阻塞接收
+func (c *Client) restarted() {
+	if !c.cfg.ClockSyncOnInit && !c.cfg.ReinterrogateOnInit {
+		return
+	}
+	c.spawn(func() error {
+		if c.cfg.ClockSyncOnInit {
+			err := c.SyncClock(c.ctx)
+			if err != nil {
+				c.Log.Errorf("从站初始化结束后时钟同步失败: %v", err)
+			}
+		}
+		if c.cfg.ReinterrogateOnInit && c.ctx.Err() == nil {
+			err := c.totalCall()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// sendI 以I帧发送asdu，发送序号自增
+func (c *Client) sendI(asdu elements.ASDU) error {
+	c.seq.mux.Lock()
+	defer c.seq.mux.Unlock()
+
+	asdu.DUI = c.cfg.Params.Apply(asdu.DUI)
 	iFrame := iec104.IFrame{
-		Send: 0,
-		Recv: 0,
+		Send: c.seq.vs,
+		Recv: c.seq.vr,
+	}
+	apdu, err := iec104.BuildAPDU(iFrame, &asdu)
+	if err != nil {
+		return fmt.Errorf("I帧创建异常: %w", err)
 	}
-	apci, _ := iec104.NewAPCI(14, iFrame)
-	asdu := elements.NewASDUC_IC_NA_1(elements.COT_ACT, 0x01, byte(elements.QOI_GLOBAL_CALL))
-	apdu, _ := iec104.NewAPDU(apci, &asdu)
 
-	_, err := c.conn.Write(apdu.ConvertBytes())
+	err = c.write(apdu)
 	if err != nil {
-		return fmt.Errorf("总召唤发送异常: %v", err)
+		return err
 	}
+	c.seq.vs = (c.seq.vs + 1) & 0x7FFF
 	return nil
 }
 
-func (c Client) uFrameResp() {
-	defer c.cancel()
-	c.Log.Info("U帧响应线程启动")
+// write 编码并发送apdu，复用写缓冲区
+func (c *Client) write(apdu iec104.APDU) error {
+	c.wmux.Lock()
+	defer c.wmux.Unlock()
+	c.wbuf = apdu.AppendTo(c.wbuf[:0])
+	if logger.DebugEnabled(c.Log) {
+		c.Log.Debugf("发送: [% X]", c.wbuf)
+	}
+	_, err := c.conn.Write(c.wbuf)
+	return err
+}
+
+func (c *Client) uFrameResp() error {
+	c.Log.Infof("U帧响应线程启动")
 	for {
 		select {
 		case <-c.ctx.Done():
-			c.Log.Info("U帧接收线程停止")
+			c.Log.Infof("U帧接收线程停止")
+			return nil
 		case apdu := <-c.ctrChan:
 			c.Log.Debugf("接收U帧[%v]", apdu)
 			uFrame := apdu.CtrFrame.(iec104.UFrame)
 			if uFrame.STARTDT_ACT {
 				uFrame.STARTDT_ACT = false
 				uFrame.STARTDT_CON = true
-				apci, _ := iec104.NewAPCI(iec104.ApciLen, uFrame)
-				resp, _ := iec104.NewAPDU(apci, nil)
-				_, err := c.conn.Write(resp.ConvertBytes())
-				if err != nil {
-					c.Log.Errorf("响应U帧[%v]异常: %v", apdu, err)
-					continue
-				}
-				c.Log.Debugf("响应U帧[%v]", resp)
 			} else if uFrame.STOPDT_ACT {
 				uFrame.STOPDT_ACT = false
 				uFrame.STOPDT_CON = true
-				apci, _ := iec104.NewAPCI(iec104.ApciLen, uFrame)
-				resp, _ := iec104.NewAPDU(apci, nil)
-				_, err := c.conn.Write(resp.ConvertBytes())
-				if err != nil {
-					c.Log.Errorf("响应U帧[%v]异常: %v", apdu, err)
-					continue
-				}
-				c.Log.Debugf("响应U帧[%v]", resp)
 			} else if uFrame.TESTFR_ACT {
 				uFrame.TESTFR_ACT = false
 				uFrame.TESTFR_CON = true
-				apci, _ := iec104.NewAPCI(iec104.ApciLen, uFrame)
-				resp, _ := iec104.NewAPDU(apci, nil)
-				_, err := c.conn.Write(resp.ConvertBytes())
-				if err != nil {
-					c.Log.Errorf("响应U帧[%v]异常: %v", apdu, err)
-					continue
-				}
-				c.Log.Debugf("响应U帧[%v]", resp)
 			} else {
-				c.Log.Debugf("U帧无需响应")
+				// 确认帧交给等待中的U帧命令
+				select {
+				case c.conChan <- apdu:
+				default:
+					c.Log.Debugf("U帧无需响应")
+				}
+				continue
+			}
+			resp, _ := iec104.BuildAPDU(uFrame, nil)
+			err := c.write(resp)
+			if err != nil {
+				return fmt.Errorf("响应U帧[%v]异常: %w", apdu, err)
 			}
+			c.Log.Debugf("响应U帧[%v]", resp)
 		}
 	}
 }
 
-func (c Client) reconnect() error {
-	err := c.stop()
-	if err != nil {
-		return err
-	}
-	err = c.start()
-	if err != nil {
-		return err
-	}
-	err = c.totalCall()
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-func (c Client) read() {
-	defer c.cancel()
-	c.Log.Info("socket读线程启动")
+func (c *Client) read() error {
+	c.Log.Infof("socket读线程启动")
+	defer c.Log.Infof("socket读线程停止")
+	reader := bufio.NewReader(c.conn)
 	for {
-		select {
-		case <-c.ctx.Done():
-			c.Log.Info("socket读线程停止")
-		default:
-		}
-		c.mux.Lock()
-		buf := make([]byte, 1024)
-		n, err := c.conn.Read(buf)
+		c.conn.SetReadDeadline(time.Now().Add(c.cfg.ConnectDeadline))
+		frame, err := iec104.ReadFrame(reader)
 		if err != nil {
-			c.Log.Errorf("socket读操作异常: %v", err)
-			c.mux.Unlock()
-			return
+			if c.ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("socket读操作异常: %w", err)
 		}
 
-		c.conn.SetDeadline(time.Now().Add(connectDeadline))
-		c.Log.Debugf("下一次超时时间为: %v", time.Now().Add(connectDeadline).Format(time.RFC3339))
-
-		c.Log.Debugf("收到原始数据: [% X]", buf[:n])
-		apdu, err := iec104.ParseAPDU(buf[:n])
+		if logger.DebugEnabled(c.Log) {
+			c.Log.Debugf("收到原始数据: [% X]", frame)
+		}
+		apdu, err := iec104.ParseAPDUWithParams(frame, c.cfg.Params)
 		if err != nil {
 			c.Log.Warnf("解析APDU异常: %v", err)
-			c.mux.Unlock()
 			continue
 		}
 
+		var ch chan iec104.APDU
 		switch apdu.CtrFrame.(type) {
-		case iec104.IFrame:
-			c.dataChan <- apdu
-		case iec104.SFrame:
-			c.dataChan <- apdu
+		case iec104.IFrame, iec104.SFrame:
+			ch = c.dataChan
 		case iec104.UFrame:
-			c.ctrChan <- apdu
+			ch = c.ctrChan
+		}
+		select {
+		case ch <- apdu:
+		case <-c.ctx.Done():
+			return nil
 		}
-		c.mux.Unlock()
 	}
 }
 
-func (c Client) writeUFrame(apdu iec104.APDU) (iec104.APDU, error) {
-	c.mux.Lock()
-	defer c.mux.Unlock()
+// writeUFrame 发送U帧命令并等待确认
+func (c *Client) writeUFrame(apdu iec104.APDU) (iec104.APDU, error) {
+	c.umux.Lock()
+	defer c.umux.Unlock()
 
-	_, err := c.conn.Write(apdu.ConvertBytes())
+	// 丢弃之前超时未处理的确认帧
+	select {
+	case <-c.conChan:
+	default:
+	}
+
+	err := c.write(apdu)
 	if err != nil {
 		return iec104.APDU{}, err
 	}
 
-	c.Log.Debugf("发送: [%X]", apdu.ConvertBytes())
-	for {
-
-		buf := make([]byte, 1024)
-		n, err := c.conn.Read(buf)
-		if err != nil {
-			return iec104.APDU{}, fmt.Errorf("socket读操作异常: %v", err)
-		}
-
-		c.conn.SetDeadline(time.Now().Add(connectDeadline))
-		c.Log.Debugf("下一次超时时间为: %v", time.Now().Add(connectDeadline).Format(time.RFC3339))
-
-		apdu, err := iec104.ParseAPDU(buf[:n])
-		if err != nil {
-			return iec104.APDU{}, fmt.Errorf("解析APDU异常: %v", err)
-		}
-
-		switch f := apdu.CtrFrame.(type) {
-		case iec104.IFrame:
-			c.dataChan <- apdu
-		case iec104.SFrame:
-			c.dataChan <- apdu
-		case iec104.UFrame:
-			if f.STARTDT_ACT || f.STOPDT_ACT || f.TESTFR_ACT {
-				c.ctrChan <- apdu
-			}
-			return apdu, nil
-		}
+	timer := time.NewTimer(c.cfg.Timeout)
+	defer timer.Stop()
+	select {
+	case resp := <-c.conChan:
+		return resp, nil
+	case <-timer.C:
+		return iec104.APDU{}, fmt.Errorf("等待U帧确认超时(%v): %w", c.cfg.Timeout, ErrTimeout)
+	case <-c.ctx.Done():
+		return iec104.APDU{}, ErrClosed
 	}
 }
 
-func handleData(apdu iec104.APDU) (map[string]float32, error) {
-	switch apdu.ASDU.DUI.TypeIdentification {
-	case elements.M_ME_NC_1:
-		values := make(map[string]float32)
-		switch mb := apdu.ASDU.MessageBody.(type) {
-		case elements.MessageElement_13_SQ_1:
-			address := int(mb.Address)
-			for _, e := range mb.Cores {
-				//TODO:考虑QDS
-				values[fmt.Sprintf("%X", address)] = e.Value
-				address++
-			}
-			return values, nil
-		case elements.MessageElement_13_SQ_0:
-			for _, e := range mb {
-				//TODO:考虑QDS
-				values[fmt.Sprintf("%X", e.Address)] = e.Core.Value
-			}
-			return values, nil
-		default:
-			return nil, fmt.Errorf("未知信息元素类型[%T]", mb)
+func handleData(apdu iec104.APDU, points *PointMap) (map[string]float32, error) {
+	dui := apdu.ASDU.DUI
+	values := make(map[string]float32)
+	put := func(address uint32, value float32) {
+		p, ok := points.Lookup(dui.CommonAddress(), address, dui.TypeIdentification)
+		if !ok {
+			values[fmt.Sprintf("%X", address)] = value
+			return
 		}
-	case elements.M_ME_NA_1:
-		values := make(map[string]float32)
-		switch mb := apdu.ASDU.MessageBody.(type) {
-		case elements.MessageElement_9_SQ_1:
-			address := int(mb.Address)
-			for _, e := range mb.Cores {
-				//TODO:考虑QDS
-				values[fmt.Sprintf("%X", address)] = float32(e.Value)
-				address++
-			}
-			return values, nil
-		case elements.MessageElement_9_SQ_0:
-			for _, e := range mb {
-				//TODO:考虑QDS
-				values[fmt.Sprintf("%X", e.Address)] = float32(e.Core.Value)
-			}
-			return values, nil
-		default:
-			return nil, fmt.Errorf("未知信息元素类型[%T]", mb)
+		values[p.Tag] = p.Apply(value)
+	}
+
+	objs := apdu.ASDU.Objects()
+	if len(objs) == 0 {
+		return nil, fmt.Errorf("未支持ASDU类型: %v", dui.TypeIdentification)
+	}
+	for _, o := range objs {
+		m, ok := o.(elements.Measurand)
+		if !ok {
+			return nil, fmt.Errorf("未支持ASDU类型: %v", dui.TypeIdentification)
 		}
-	default:
-		return nil, fmt.Errorf("未支持ASDU类型: %v", apdu.ASDU.DUI.TypeIdentification)
+		//TODO:考虑QDS
+		put(o.IOA(), float32(m.Float()))
 	}
+	return values, nil
 }
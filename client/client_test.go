@@ -1,349 +1,568 @@
 package client
 
 import (
-	"log"
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"log/slog"
 	"net"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/wangxianzhuo/iec104/msg-elements"
 
-	"golang.org/x/net/context"
-
-	"github.com/sirupsen/logrus"
 	"github.com/wangxianzhuo/iec104"
+	"github.com/wangxianzhuo/iec104/logger"
 )
 
 func Test_start(t *testing.T) {
-	ctx, _ := context.WithCancel(context.Background())
-	address := "127.0.0.1:2404"
+	server := newTestServer(t, nil)
+	c, done := startClient(t, server.Addr(), nil)
 
-	uFrame := iec104.UFrame{
-		STARTDT_CON: true,
-	}
-	apci, err := iec104.NewAPCI(iec104.ApciLen, uFrame)
-	if err != nil {
-		t.Errorf("启动帧控制域创建异常: %v", err)
-	}
+	apdu := server.expect(t, isUFrame(func(f iec104.UFrame) bool { return f.STARTDT_ACT }))
+	t.Logf("收到启动帧: [%X]", apdu.ConvertBytes())
 
-	apdu, err := iec104.NewAPDU(apci, nil)
-	if err != nil {
-		t.Errorf("启动帧创建异常: %v", err)
+	c.Close()
+	if err := <-done; err != nil {
+		t.Fatal(err)
 	}
+}
 
-	go echoServer(":2404", apdu.ConvertBytes(), ctx)
+func Test_test(t *testing.T) {
+	server := newTestServer(t, nil)
+	c, done := startClient(t, server.Addr(), func(cfg *Config) {
+		cfg.TestInterval = 50 * time.Millisecond
+	})
 
-	time.Sleep(1 * time.Second)
+	testfr := isUFrame(func(f iec104.UFrame) bool { return f.TESTFR_ACT })
+	server.expect(t, testfr)
+	server.expect(t, testfr)
 
-	c := startClient(address)
-	err = c.start()
-	if err != nil {
+	c.Close()
+	if err := <-done; err != nil {
 		t.Fatal(err)
 	}
 }
-func Test_test(t *testing.T) {
-	ctx, _ := context.WithCancel(context.Background())
-	address := "127.0.0.1:2404"
 
-	uFrame := iec104.UFrame{
-		TESTFR_CON: true,
+func Test_stop(t *testing.T) {
+	server := newTestServer(t, nil)
+	c, done := startClient(t, server.Addr(), nil)
+	server.expect(t, isIFrame(elements.C_IC_NA_1))
+
+	c.Close()
+	server.expect(t, isUFrame(func(f iec104.UFrame) bool { return f.STOPDT_ACT }))
+	if err := <-done; err != nil {
+		t.Fatal(err)
 	}
-	apci, err := iec104.NewAPCI(iec104.ApciLen, uFrame)
-	if err != nil {
-		t.Errorf("启动帧控制域创建异常: %v", err)
+	// Close可重复调用
+	c.Close()
+	if err := c.Run(context.Background()); err == nil {
+		t.Fatalf("关闭后Run应返回异常")
 	}
+}
 
-	apdu, err := iec104.NewAPDU(apci, nil)
-	if err != nil {
-		t.Errorf("启动帧创建异常: %v", err)
+func Test_totalCall(t *testing.T) {
+	data, _ := hex.DecodeString("683A000000000D060300010009400023DB114000064000753C583F000140009417C340000E4000CD4C7A42000240009D68273C00044000FF04084000")
+	server := newTestServer(t, func(conn net.Conn, apdu iec104.APDU) {
+		if apdu.ASDU.DUI.TypeIdentification != elements.C_IC_NA_1 {
+			return
+		}
+		writeI(conn, elements.NewASDUC_IC_NA_1(elements.COT_ACTCON, 1, elements.QOI_GLOBAL_CALL), 0)
+		conn.Write(data)
+		writeI(conn, elements.NewASDUC_IC_NA_1(elements.COT_ACTTERM, 1, elements.QOI_GLOBAL_CALL), 2)
+	})
+	outChan := make(chan map[string]float32, 1)
+	c, done := startClient(t, server.Addr(), func(cfg *Config) {
+		cfg.Output = outChan
+	})
+
+	select {
+	case values := <-outChan:
+		if len(values) != 6 {
+			t.Fatalf("总召唤数据[%v]异常", values)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("等待总召唤数据超时")
 	}
+	// 每个I帧都应被S帧确认
+	server.expect(t, func(apdu iec104.APDU) bool {
+		f, ok := apdu.CtrFrame.(iec104.SFrame)
+		return ok && f.Recv == 3
+	})
 
-	go echoServer(":2404", apdu.ConvertBytes(), ctx)
-
-	time.Sleep(1 * time.Second)
-
-	c := startClient(address)
-	err = c.test()
-	if err != nil {
+	c.Close()
+	if err := <-done; err != nil {
 		t.Fatal(err)
 	}
+}
 
-	err = c.test()
-	if err != nil {
+func Test_SendBitstring(t *testing.T) {
+	var send int16
+	server := newTestServer(t, func(conn net.Conn, apdu iec104.APDU) {
+		cmd, ok := apdu.ASDU.MessageBody.(elements.MessageElement_51)
+		if !ok {
+			return
+		}
+		if cmd.Address == 0x6003 {
+			// 不响应，等待超时
+			return
+		}
+		resp := elements.NewASDUC_BO_NA_1(elements.COT_ACTCON, 1, cmd.Address, cmd.BSI)
+		resp.DUI.COT.Negative = cmd.BSI == 0
+		writeI(conn, resp, send)
+		send++
+	})
+	c, done := startClient(t, server.Addr(), func(cfg *Config) {
+		cfg.Timeout = 200 * time.Millisecond
+	})
+	server.expect(t, isIFrame(elements.C_IC_NA_1))
+
+	ctx := context.Background()
+	if err := c.SendBitstring(ctx, 0x6001, 0x80000001); err != nil {
 		t.Fatal(err)
 	}
-}
-func Test_init(t *testing.T) {
-	ctx, _ := context.WithCancel(context.Background())
-	address := "127.0.0.1:2404"
-
-	uFrame := iec104.UFrame{
-		STOPDT_CON: true,
+	apdu := server.expect(t, isIFrame(elements.C_BO_NA_1))
+	if cmd := apdu.ASDU.MessageBody.(elements.MessageElement_51); cmd.Address != 0x6001 || cmd.BSI != 0x80000001 || apdu.ASDU.DUI.COT.Cause != elements.COT_ACT {
+		t.Fatalf("比特串命令[%v]错误", apdu.ASDU)
 	}
-	apci, err := iec104.NewAPCI(iec104.ApciLen, uFrame)
-	if err != nil {
-		t.Errorf("启动帧控制域创建异常: %v", err)
+	err := c.SendBitstring(ctx, 0x6002, 0)
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) || !errors.Is(err, ErrNegativeConfirm) || !cmdErr.COT.Negative {
+		t.Fatalf("否定确认应返回ErrNegativeConfirm: %v", err)
 	}
-
-	apdu, err := iec104.NewAPDU(apci, nil)
-	if err != nil {
-		t.Errorf("启动帧创建异常: %v", err)
+	if err := c.SendBitstring(ctx, 0x6003, 1); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("未响应应返回ErrTimeout: %v", err)
 	}
 
-	uFrame2 := iec104.UFrame{
-		STARTDT_CON: true,
+	c.Close()
+	if err := <-done; err != nil {
+		t.Fatal(err)
 	}
-	apci2, err := iec104.NewAPCI(iec104.ApciLen, uFrame2)
-	if err != nil {
-		t.Errorf("启动帧控制域创建异常: %v", err)
+	if err := c.SendBitstring(ctx, 0x6001, 1); !errors.Is(err, ErrClosed) {
+		t.Fatalf("关闭后发送命令的异常[%v]错误", err)
 	}
+}
 
-	apdu2, err := iec104.NewAPDU(apci2, nil)
-	if err != nil {
-		t.Errorf("启动帧创建异常: %v", err)
+func Test_stationRestart(t *testing.T) {
+	var send int16
+	var calls int
+	server := newTestServer(t, func(conn net.Conn, apdu iec104.APDU) {
+		switch apdu.ASDU.DUI.TypeIdentification {
+		case elements.C_IC_NA_1:
+			calls++
+			if calls > 1 {
+				return
+			}
+			// 首次总召唤后模拟从站重启
+			writeI(conn, elements.ASDU{
+				DUI: elements.DefaultParams.Apply(elements.DUI{
+					TypeIdentification:         elements.M_EI_NA_1,
+					VariableStructureQualifier: 1,
+					COT:                        elements.COT{Cause: elements.COT_INIT},
+					PublicAddressLow:           1,
+				}),
+				MessageBody: elements.MessageElement_70{COI: elements.COI{Cause: elements.COI_REMOTE_RESET, ParamsChanged: true}},
+			}, send)
+			send++
+		case elements.C_CS_NA_1:
+			cmd := apdu.ASDU.MessageBody.(elements.MessageElement_103)
+			writeI(conn, elements.NewASDUC_CS_NA_1(elements.COT_ACTCON, 1, cmd.Time), send)
+			send++
+		}
+	})
+	events := make(chan Event, 1)
+	c, done := startClient(t, server.Addr(), func(cfg *Config) {
+		cfg.Events = events
+		cfg.ReinterrogateOnInit = true
+		cfg.ClockSyncOnInit = true
+	})
+	server.expect(t, isIFrame(elements.C_IC_NA_1))
+
+	select {
+	case e := <-events:
+		r, ok := e.(*StationRestartEvent)
+		if !ok || r.CommonAddress != 1 || r.COI.Cause != elements.COI_REMOTE_RESET || !r.COI.ParamsChanged {
+			t.Fatalf("从站重启事件[%+v]错误", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("等待从站重启事件超时")
 	}
+	apdu := server.expect(t, isIFrame(elements.C_CS_NA_1))
+	if apdu.ASDU.DUI.COT.Cause != elements.COT_ACT {
+		t.Fatalf("时钟同步命令[%v]错误", apdu.ASDU)
+	}
+	server.expect(t, isIFrame(elements.C_IC_NA_1))
 
-	go echoServer2(":2404", [][]byte{apdu.ConvertBytes(), apdu2.ConvertBytes()}, ctx)
-
-	time.Sleep(1 * time.Second)
-
-	c := startClient(address)
-	err = c.init()
-	if err != nil {
+	c.Close()
+	if err := <-done; err != nil {
 		t.Fatal(err)
 	}
 }
-func Test_stop(t *testing.T) {
-	ctx, _ := context.WithCancel(context.Background())
-	address := "127.0.0.1:2404"
 
-	uFrame := iec104.UFrame{
-		STOPDT_CON: true,
-	}
-	apci, err := iec104.NewAPCI(iec104.ApciLen, uFrame)
-	if err != nil {
-		t.Errorf("启动帧控制域创建异常: %v", err)
+func Test_protectionEvent(t *testing.T) {
+	when := time.Date(2018, 10, 7, 13, 45, 59, 123*int(time.Millisecond), time.UTC)
+	asdu := elements.ASDU{
+		DUI: elements.DefaultParams.Apply(elements.DUI{
+			TypeIdentification:         elements.M_EP_TD_1,
+			VariableStructureQualifier: 1,
+			COT:                        elements.COT{Cause: elements.COT_SPONT},
+			PublicAddressLow:           1,
+		}),
+		MessageBody: elements.MessageElement_38_SQ_0{{
+			Address: 0x7001,
+			Core: elements.MessageElementCore_38{
+				SEP:     elements.SEP{ES: elements.ES_ON},
+				Elapsed: 120,
+				Time:    elements.NewCP56Time2a(when),
+			},
+		}},
+	}
+	server := newTestServer(t, func(conn net.Conn, apdu iec104.APDU) {
+		if apdu.ASDU.DUI.TypeIdentification == elements.C_IC_NA_1 {
+			writeI(conn, asdu, 0)
+		}
+	})
+	events := make(chan Event, 1)
+	c, done := startClient(t, server.Addr(), func(cfg *Config) {
+		cfg.Events = events
+		cfg.Location = time.UTC
+	})
+
+	select {
+	case e := <-events:
+		pe, ok := e.(*ProtectionEvent)
+		if !ok || pe.Address != 0x7001 || pe.Tag != "7001" || !pe.Time.Equal(when) || pe.Elapsed != 120*time.Millisecond {
+			t.Fatalf("继电保护设备事件[%+v]错误", e)
+		}
+		if pe.Object.(elements.MessageElement_38_SQ_0_Ele).Core.SEP.ES != elements.ES_ON {
+			t.Fatalf("事件状态错误: %+v", pe.Object)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("等待继电保护设备事件超时")
 	}
 
-	apdu, err := iec104.NewAPDU(apci, nil)
-	if err != nil {
-		t.Errorf("启动帧创建异常: %v", err)
+	c.Close()
+	if err := <-done; err != nil {
+		t.Fatal(err)
 	}
+}
 
-	go echoServer(":2404", apdu.ConvertBytes(), ctx)
+func Test_uFrameResp(t *testing.T) {
+	server := newTestServer(t, func(conn net.Conn, apdu iec104.APDU) {
+		if apdu.ASDU.DUI.TypeIdentification != elements.C_IC_NA_1 {
+			return
+		}
+		writeU(conn, iec104.UFrame{TESTFR_ACT: true})
+	})
+	c, done := startClient(t, server.Addr(), nil)
 
-	time.Sleep(1 * time.Second)
+	server.expect(t, isUFrame(func(f iec104.UFrame) bool { return f.TESTFR_CON }))
 
-	c := startClient(address)
-	err = c.start()
-	if err != nil {
+	c.Close()
+	if err := <-done; err != nil {
 		t.Fatal(err)
 	}
 }
 
-func Test_connectionTest(t *testing.T) {
-	ctx, _ := context.WithCancel(context.Background())
-	address := "127.0.0.1:2404"
-
-	uFrame := iec104.UFrame{
-		TESTFR_CON: true,
-	}
-	apci, err := iec104.NewAPCI(iec104.ApciLen, uFrame)
-	if err != nil {
-		t.Errorf("启动帧控制域创建异常: %v", err)
-	}
-
-	apdu, err := iec104.NewAPDU(apci, nil)
-	if err != nil {
-		t.Errorf("启动帧创建异常: %v", err)
+func Test_runError(t *testing.T) {
+	server := newTestServer(t, func(conn net.Conn, apdu iec104.APDU) {
+		if apdu.ASDU.DUI.TypeIdentification == elements.C_IC_NA_1 {
+			conn.Close()
+		}
+	})
+	c, done := startClient(t, server.Addr(), nil)
+	defer c.Close()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatalf("连接断开时Run应返回异常")
+		}
+		t.Logf("Run返回: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("等待Run返回超时")
 	}
-
-	go echoServer(":2404", apdu.ConvertBytes(), ctx)
-
-	time.Sleep(1 * time.Second)
-
-	c := startClient(address)
-	c.connectionTest(5 * time.Second)
 }
-func Test_totalCall(t *testing.T) {
-	ctx, _ := context.WithCancel(context.Background())
-	address := "127.0.0.1:2404"
 
-	iFrame := iec104.IFrame{
-		Send: 1,
-		Recv: 0,
+func Test_packedPointEvents(t *testing.T) {
+	asdu := elements.ASDU{
+		DUI: elements.DUI{TypeIdentification: elements.M_PS_NA_1, VariableStructureQualifier: 1, PublicAddressLow: 1},
+		MessageBody: elements.MessageElement_20_SQ_0{{
+			Address: 0x0101,
+			// 第0个遥信为合且未变位，第1个遥信为分但期间变位过
+			Core: elements.MessageElementCore_20{SCD: elements.SCD{ST: 0x0001, CD: 0x0002}},
+		}},
 	}
-	apci, _ := iec104.NewAPCI(14, iFrame)
-	asdu := elements.NewASDUC_IC_NA_1(elements.COT_ACTCON, 0x01, byte(elements.QOI_GLOBAL_CALL))
-	apdu, err := iec104.NewAPDU(apci, &asdu)
+	points, err := NewPointMap([]PointConfig{{CommonAddress: 1, IOA: 0x0101, Tag: "Breakers"}})
 	if err != nil {
 		t.Fatal(err)
 	}
+	events := toEvents(asdu, points, time.UTC)
+	if len(events) != 16 {
+		t.Fatalf("成组单点信息应产生16个事件，实际为%d个", len(events))
+	}
+	e0, e1 := events[0].(*PackedPointEvent), events[1].(*PackedPointEvent)
+	if e0.Tag != "Breakers.0" || !e0.Value || e0.Changed {
+		t.Fatalf("第0个遥信[%+v]错误", e0)
+	}
+	if e1.Index != 1 || e1.Value || !e1.Changed {
+		t.Fatalf("第1个遥信[%+v]错误", e1)
+	}
 
-	go echoServer(":2404", apdu.ConvertBytes(), ctx)
-
-	time.Sleep(1 * time.Second)
-
-	c := startClient(address)
-	err = c.totalCall()
+	// 取反的遥信点位
+	points, err = NewPointMap([]PointConfig{{CommonAddress: 1, IOA: 0x0101, TypeID: elements.M_PS_NA_1, Tag: "Breakers", Invert: true}})
 	if err != nil {
 		t.Fatal(err)
 	}
+	events = toEvents(asdu, points, time.UTC)
+	if e0 := events[0].(*PackedPointEvent); e0.Value {
+		t.Fatalf("取反后第0个遥信[%+v]错误", e0)
+	}
 }
-func Test_uFrameResp(t *testing.T) {
-	ctx, _ := context.WithCancel(context.Background())
-	address := "127.0.0.1:2404"
 
-	iFrame := iec104.IFrame{
-		Send: 1,
-		Recv: 0,
-	}
-	apci, _ := iec104.NewAPCI(14, iFrame)
-	asdu := elements.NewASDUC_IC_NA_1(elements.COT_ACTCON, 0x01, byte(elements.QOI_GLOBAL_CALL))
-	apdu, err := iec104.NewAPDU(apci, &asdu)
+func Test_LoadPointMapCSV(t *testing.T) {
+	csv := `common_address,ioa,type,tag,unit,scale,offset,invert
+1,0x4001,13,P1,kW,1000,0,false
+1,0x4002,20,Breaker1,,,,true
+# 注释行
+2,16387,9,U1,kV,220,0`
+	points, err := LoadPointMapCSV(strings.NewReader(csv))
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	go echoServer(":2404", apdu.ConvertBytes(), ctx)
-	time.Sleep(1 * time.Second)
-
-	uFrame1 := iec104.UFrame{
-		STARTDT_ACT: true,
+	p, ok := points.Lookup(1, 0x4001, elements.M_ME_NC_1)
+	if !ok || p.Tag != "P1" || p.Unit != "kW" || p.Apply(1.5) != 1500 {
+		t.Fatalf("点位[0x4001]解析异常: %v", p)
 	}
-	apci1, err := iec104.NewAPCI(iec104.ApciLen, uFrame1)
-	if err != nil {
-		t.Errorf("启动帧控制域创建异常: %v", err)
+	p, ok = points.Lookup(1, 0x4002, elements.M_PS_NA_1)
+	if !ok || p.Tag != "Breaker1" || !p.Invert {
+		t.Fatalf("点位[0x4002]解析异常: %v", p)
 	}
-	apdu1, err := iec104.NewAPDU(apci1, nil)
-	if err != nil {
-		t.Errorf("启动帧创建异常: %v", err)
+	if _, ok := points.Lookup(1, 0x4003, elements.M_ME_NA_1); ok {
+		t.Fatalf("点位[1/0x4003]不应存在")
 	}
 
-	uFrame2 := iec104.UFrame{
-		STOPDT_ACT: true,
+	asdu := elements.ASDU{
+		DUI: elements.DUI{
+			TypeIdentification:     elements.M_ME_NA_1,
+			PublicAddressLow:       2,
+			PublicAddressHigEnable: true,
+		},
+		MessageBody: elements.MessageElement_9_SQ_1{
+			Address: 16387,
+			Cores:   []elements.MessageElementCore_9{{Value: elements.NewNVA(0.5)}, {Value: elements.NewNVA(-0.25)}},
+		},
 	}
-	apci2, err := iec104.NewAPCI(iec104.ApciLen, uFrame2)
+	data, err := handleData(iec104.APDU{ASDU: asdu}, points)
 	if err != nil {
-		t.Errorf("启动帧控制域创建异常: %v", err)
+		t.Fatal(err)
 	}
-	apdu2, err := iec104.NewAPDU(apci2, nil)
-	if err != nil {
-		t.Errorf("启动帧创建异常: %v", err)
+	if data["U1"] != 110 || data["4004"] != -0.25 {
+		t.Fatalf("点表映射结果异常: %v", data)
 	}
 
-	uFrame3 := iec104.UFrame{
-		TESTFR_ACT: true,
+	_, err = LoadPointMapCSV(strings.NewReader("common_address,ioa,type,tag\n1,1,13,A\n1,1,13,B"))
+	if err == nil {
+		t.Fatalf("重复点位应返回异常")
 	}
-	apci3, err := iec104.NewAPCI(iec104.ApciLen, uFrame3)
+
+	// 按列名对应字段
+	points, err = LoadPointMapCSV(strings.NewReader("tag,ioa,common_address,scale\nP2,0x4005,1,10"))
 	if err != nil {
-		t.Errorf("启动帧控制域创建异常: %v", err)
+		t.Fatal(err)
 	}
-	apdu3, err := iec104.NewAPDU(apci3, nil)
-	if err != nil {
-		t.Errorf("启动帧创建异常: %v", err)
+	if p, ok := points.Lookup(1, 0x4005, elements.M_ME_NC_1); !ok || p.Tag != "P2" || p.Apply(1) != 10 {
+		t.Fatalf("点位[0x4005]解析异常: %v", p)
+	}
+	for _, bad := range []string{
+		"1,0x4001,13,P1",
+		"common_address,ioa,tag,value\n1,1,A,1",
+		"common_address,tag\n1,A",
+		"common_address,ioa,type,tag,invert\n1,1,13,A,true",
+	} {
+		if _, err := LoadPointMapCSV(strings.NewReader(bad)); err == nil {
+			t.Fatalf("点表[%q]应返回异常", bad)
+		}
+	}
+}
+
+func Test_scheduler(t *testing.T) {
+	s := Schedule{Interval: time.Hour, Align: true}
+	now := time.Date(2018, 10, 1, 8, 25, 0, 0, time.UTC)
+	if next := s.next(now); !next.Equal(time.Date(2018, 10, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("整点对齐时间[%v]异常", next)
 	}
 
-	uFrame4 := iec104.UFrame{
-		STARTDT_CON: true,
+	sched := newScheduler(nil)
+	sched.begin(elements.C_CI_NA_1)
+	actcon := elements.NewASDUC_CI_NA_1(elements.COT_ACTCON, 1, elements.QCC_RQT_GENERAL)
+	if !sched.confirm(actcon) {
+		t.Fatalf("计数量召唤确认应由调度器处理")
 	}
-	apci4, err := iec104.NewAPCI(iec104.ApciLen, uFrame4)
-	if err != nil {
-		t.Errorf("启动帧控制域创建异常: %v", err)
+	select {
+	case <-sched.done:
+		t.Fatalf("激活确认不应结束召唤")
+	default:
 	}
-	apdu4, err := iec104.NewAPDU(apci4, nil)
-	if err != nil {
-		t.Errorf("启动帧创建异常: %v", err)
+	negative := elements.NewASDUC_CI_NA_1(elements.COT_ACTCON, 1, elements.QCC_RQT_GENERAL)
+	negative.DUI.COT.Negative = true
+	sched.confirm(negative)
+	if err := <-sched.done; !errors.Is(err, ErrNegativeConfirm) {
+		t.Fatalf("否定确认应返回ErrNegativeConfirm: %v", err)
 	}
 
-	c := startClient(address)
-	go c.uFrameResp()
-	c.ctrChan <- apdu1
-	c.ctrChan <- apdu2
-	c.ctrChan <- apdu3
-	c.ctrChan <- apdu4
+	sched.begin(elements.C_IC_NA_1)
+	sched.confirm(elements.NewASDUC_IC_NA_1(elements.COT_ACTTERM, 1, elements.QOI_GLOBAL_CALL))
+	if err := <-sched.done; err != nil {
+		t.Fatal(err)
+	}
+}
 
+// testServer 测试用从站，自动确认U帧命令，I帧交给handler处理
+type testServer struct {
+	l        net.Listener
+	received chan iec104.APDU
+	handler  func(conn net.Conn, apdu iec104.APDU)
 }
 
-func echoServer(address string, resp []byte, ctx context.Context) error {
-	defer log.Printf("echo server stop")
-	l, err := net.Listen("tcp", address)
+func newTestServer(t *testing.T, handler func(conn net.Conn, apdu iec104.APDU)) *testServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
-		return err
+		t.Fatal(err)
 	}
-	defer l.Close()
-	log.Printf("echo server start")
+	s := &testServer{
+		l:        l,
+		received: make(chan iec104.APDU, 64),
+		handler:  handler,
+	}
+	t.Cleanup(func() { l.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *testServer) Addr() string {
+	return s.l.Addr().String()
+}
 
-	conn, err := l.Accept()
+func (s *testServer) serve() {
+	conn, err := s.l.Accept()
 	if err != nil {
-		return err
+		return
 	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
 	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		default:
+		frame, err := iec104.ReadFrame(reader)
+		if err != nil {
+			return
 		}
-
-		buf := make([]byte, 1024)
-		n, err := conn.Read(buf)
+		apdu, err := iec104.ParseAPDU(frame)
 		if err != nil {
-			return err
+			return
+		}
+		s.received <- apdu
+
+		switch f := apdu.CtrFrame.(type) {
+		case iec104.UFrame:
+			switch {
+			case f.STARTDT_ACT:
+				writeU(conn, iec104.UFrame{STARTDT_CON: true})
+			case f.STOPDT_ACT:
+				writeU(conn, iec104.UFrame{STOPDT_CON: true})
+			case f.TESTFR_ACT:
+				writeU(conn, iec104.UFrame{TESTFR_CON: true})
+			}
+		case iec104.IFrame:
+			if s.handler != nil {
+				s.handler(conn, apdu)
+			}
 		}
+	}
+}
 
-		log.Printf("READ: [%X]", buf[:n])
-		_, err = conn.Write(resp)
-		if err != nil {
-			return err
+// expect 等待满足条件的APDU
+func (s *testServer) expect(t *testing.T, match func(iec104.APDU) bool) iec104.APDU {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case apdu := <-s.received:
+			if match(apdu) {
+				return apdu
+			}
+		case <-timeout:
+			t.Fatalf("等待APDU超时")
 		}
-		log.Printf("SEND: [%X]", resp)
 	}
 }
 
-func echoServer2(address string, resp [][]byte, ctx context.Context) error {
-	defer log.Printf("echo server stop")
-	l, err := net.Listen("tcp", address)
-	if err != nil {
-		return err
+func isUFrame(match func(iec104.UFrame) bool) func(iec104.APDU) bool {
+	return func(apdu iec104.APDU) bool {
+		f, ok := apdu.CtrFrame.(iec104.UFrame)
+		return ok && match(f)
 	}
-	defer l.Close()
-	log.Printf("echo server start")
+}
 
-	conn, err := l.Accept()
-	if err != nil {
-		return err
+func isIFrame(typeID byte) func(iec104.APDU) bool {
+	return func(apdu iec104.APDU) bool {
+		_, ok := apdu.CtrFrame.(iec104.IFrame)
+		return ok && apdu.ASDU.DUI.TypeIdentification == typeID
 	}
+}
 
-	i := 0
-	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		default:
-		}
-		buf := make([]byte, 1024)
-		n, err := conn.Read(buf)
-		if err != nil {
-			return err
-		}
+func writeU(conn net.Conn, f iec104.UFrame) {
+	apdu, _ := iec104.BuildAPDU(f, nil)
+	conn.Write(apdu.ConvertBytes())
+}
 
-		log.Printf("READ: [%X]", buf[:n])
-		_, err = conn.Write(resp[i])
-		if err != nil {
-			return err
-		}
-		log.Printf("SEND: [%X]", resp[i])
-		i++
-	}
+func writeI(conn net.Conn, asdu elements.ASDU, send int16) {
+	apdu, _ := iec104.BuildAPDU(iec104.IFrame{Send: send}, &asdu)
+	conn.Write(apdu.ConvertBytes())
 }
 
-func startClient(address string) Client {
-	outChan := make(chan map[string]float32)
-	logrus.SetLevel(logrus.DebugLevel)
-	c, _, err := New(address, outChan, logrus.WithField("client", "iec104"))
+func startClient(t *testing.T, address string, configure func(*Config)) (*Client, chan error) {
+	cfg := Config{
+		Address: address,
+		Log:     logger.NewSlog(slog.Default().With("client", "iec104")),
+	}
+	if configure != nil {
+		configure(&cfg)
+	}
+	c, err := Dial(context.Background(), cfg)
 	if err != nil {
-		panic(err)
+		t.Fatal(err)
 	}
 
-	return c
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Run(context.Background())
+	}()
+	return c, done
+}
+
+func Test_filesDeliver(t *testing.T) {
+	var f files
+	ch := f.begin()
+	asdu := elements.ASDU{DUI: elements.DUI{TypeIdentification: elements.F_SG_NA_1}}
+	for i := 0; i < cap(ch); i++ {
+		f.deliver(context.Background(), asdu)
+	}
+	delivered := make(chan bool)
+	go func() {
+		delivered <- f.deliver(context.Background(), asdu)
+	}()
+	// 文件操作提前结束后接收协程不能阻塞
+	f.end()
+	select {
+	case ok := <-delivered:
+		if !ok {
+			t.Fatal("文件传输报文应被处理")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("文件操作结束后投递报文阻塞")
+	}
 }
@@ -0,0 +1,253 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+// commandKey 命令以类型标识及信息对象地址区分
+type commandKey struct {
+	typeID  byte
+	address uint32
+}
+
+// commands 等待确认的命令
+type commands struct {
+	mux     sync.Mutex
+	pending map[commandKey]chan error
+}
+
+func newCommands() *commands {
+	return &commands{pending: make(map[commandKey]chan error)}
+}
+
+func keyOf(asdu elements.ASDU) (commandKey, bool) {
+	objs := asdu.Objects()
+	if len(objs) != 1 {
+		return commandKey{}, false
+	}
+	return commandKey{typeID: asdu.DUI.TypeIdentification, address: objs[0].IOA()}, true
+}
+
+// begin 登记等待确认的命令，同一命令已在等待确认时返回false
+func (cs *commands) begin(key commandKey) (chan error, bool) {
+	cs.mux.Lock()
+	defer cs.mux.Unlock()
+	if _, ok := cs.pending[key]; ok {
+		return nil, false
+	}
+	done := make(chan error, 1)
+	cs.pending[key] = done
+	return done, true
+}
+
+// end 清除等待确认的命令
+func (cs *commands) end(key commandKey) {
+	cs.mux.Lock()
+	defer cs.mux.Unlock()
+	delete(cs.pending, key)
+}
+
+// confirm 处理命令的确认及终止报文，返回报文是否属于等待确认的命令
+//
+// 激活确认或停止激活确认时命令完成；否定确认或传送原因为未知类型标识、未知公共地址等时命令失败；
+// 激活终止被忽略。
+func (cs *commands) confirm(asdu elements.ASDU) bool {
+	key, ok := keyOf(asdu)
+	if !ok {
+		return false
+	}
+	cs.mux.Lock()
+	defer cs.mux.Unlock()
+	done, ok := cs.pending[key]
+	if !ok {
+		return false
+	}
+
+	var result error
+	cot := asdu.DUI.COT
+	switch {
+	case cot.Negative || cot.Cause >= elements.COT_UNKNOWN_TYPE && cot.Cause <= elements.COT_UNKNOWN_IOA:
+		result = &CommandError{TypeID: key.typeID, COT: cot, Err: ErrNegativeConfirm}
+	case cot.Cause == elements.COT_ACTCON, cot.Cause == elements.COT_DEACTCON:
+		result = nil
+	case cot.Cause == elements.COT_ACTTERM:
+		return true
+	default:
+		return false
+	}
+	delete(cs.pending, key)
+	done <- result
+	return true
+}
+
+// command 发送只含一个信息对象的命令并等待激活确认，超时时间为 Config.Timeout
+func (c *Client) command(ctx context.Context, asdu elements.ASDU) error {
+	if err := c.checkRunning(); err != nil {
+		return err
+	}
+
+	key, ok := keyOf(asdu)
+	if !ok {
+		return &CommandError{TypeID: asdu.DUI.TypeIdentification, Err: ErrInvalidCommand}
+	}
+	done, ok := c.cmds.begin(key)
+	if !ok {
+		return &CommandError{TypeID: key.typeID, Err: ErrCommandPending}
+	}
+	defer c.cmds.end(key)
+
+	if err := c.sendI(asdu); err != nil {
+		return err
+	}
+	c.Log.Debugf("命令[%d]信息对象地址[%X]已发送，等待确认", key.typeID, key.address)
+
+	timer := time.NewTimer(c.cfg.Timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return &CommandError{TypeID: key.typeID, Err: ErrTimeout}
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.ctx.Done():
+		return ErrClosed
+	}
+}
+
+// SyncClock 以当前时间发送时钟同步命令（C_CS_NA_1），等待从站激活确认，时标时区为 Config.Location
+func (c *Client) SyncClock(ctx context.Context) error {
+	asdu := elements.NewASDUC_CS_NA_1(elements.COT_ACT, c.cfg.CommonAddress, c.now())
+	return c.command(ctx, asdu)
+}
+
+// now 当前时间的CP56Time2a时标，时区为 Config.Location
+func (c *Client) now() elements.CP56Time2a {
+	return elements.NewCP56Time2a(time.Now().In(c.cfg.Location))
+}
+
+// SendBitstring 发送32位比特串命令（C_BO_NA_1，配置 Config.TimeTaggedCommands 时为C_BO_TA_1），等待从站激活确认
+//
+// 否定确认时返回的异常满足 errors.Is(err, ErrNegativeConfirm)，超时时满足 errors.Is(err, ErrTimeout)。
+// 单命令、双命令等其他控制命令相同。
+func (c *Client) SendBitstring(ctx context.Context, address uint32, value elements.BSI) error {
+	asdu := elements.NewASDUC_BO_NA_1(elements.COT_ACT, c.cfg.CommonAddress, address, value)
+	if c.cfg.TimeTaggedCommands {
+		asdu = elements.NewASDUC_BO_TA_1(elements.COT_ACT, c.cfg.CommonAddress, address, value, c.now())
+	}
+	return c.command(ctx, asdu)
+}
+
+// SingleCommand 发送单命令（C_SC_NA_1或C_SC_TA_1），等待从站激活确认
+func (c *Client) SingleCommand(ctx context.Context, address uint32, sco elements.SCO) error {
+	asdu := elements.NewASDUC_SC_NA_1(elements.COT_ACT, c.cfg.CommonAddress, address, sco)
+	if c.cfg.TimeTaggedCommands {
+		asdu = elements.NewASDUC_SC_TA_1(elements.COT_ACT, c.cfg.CommonAddress, address, sco, c.now())
+	}
+	return c.command(ctx, asdu)
+}
+
+// DoubleCommand 发送双命令（C_DC_NA_1或C_DC_TA_1），等待从站激活确认
+func (c *Client) DoubleCommand(ctx context.Context, address uint32, dco elements.DCO) error {
+	asdu := elements.NewASDUC_DC_NA_1(elements.COT_ACT, c.cfg.CommonAddress, address, dco)
+	if c.cfg.TimeTaggedCommands {
+		asdu = elements.NewASDUC_DC_TA_1(elements.COT_ACT, c.cfg.CommonAddress, address, dco, c.now())
+	}
+	return c.command(ctx, asdu)
+}
+
+// RegulatingStep 发送步调节命令（C_RC_NA_1或C_RC_TA_1），等待从站激活确认
+func (c *Client) RegulatingStep(ctx context.Context, address uint32, rco elements.RCO) error {
+	asdu := elements.NewASDUC_RC_NA_1(elements.COT_ACT, c.cfg.CommonAddress, address, rco)
+	if c.cfg.TimeTaggedCommands {
+		asdu = elements.NewASDUC_RC_TA_1(elements.COT_ACT, c.cfg.CommonAddress, address, rco, c.now())
+	}
+	return c.command(ctx, asdu)
+}
+
+// SetpointNormalized 发送规一化值设定值命令（C_SE_NA_1或C_SE_TA_1），value为[-1, 1)区间内的小数，等待从站激活确认
+func (c *Client) SetpointNormalized(ctx context.Context, address uint32, value float64, qos elements.QOS) error {
+	nva := elements.NewNVA(value)
+	asdu := elements.NewASDUC_SE_NA_1(elements.COT_ACT, c.cfg.CommonAddress, address, nva, qos)
+	if c.cfg.TimeTaggedCommands {
+		asdu = elements.NewASDUC_SE_TA_1(elements.COT_ACT, c.cfg.CommonAddress, address, nva, qos, c.now())
+	}
+	return c.command(ctx, asdu)
+}
+
+// SetpointScaled 发送标度化值设定值命令（C_SE_NB_1或C_SE_TB_1），等待从站激活确认
+func (c *Client) SetpointScaled(ctx context.Context, address uint32, value int16, qos elements.QOS) error {
+	asdu := elements.NewASDUC_SE_NB_1(elements.COT_ACT, c.cfg.CommonAddress, address, value, qos)
+	if c.cfg.TimeTaggedCommands {
+		asdu = elements.NewASDUC_SE_TB_1(elements.COT_ACT, c.cfg.CommonAddress, address, value, qos, c.now())
+	}
+	return c.command(ctx, asdu)
+}
+
+// SetpointFloat 发送短浮点数设定值命令（C_SE_NC_1或C_SE_TC_1），等待从站激活确认
+func (c *Client) SetpointFloat(ctx context.Context, address uint32, value float32, qos elements.QOS) error {
+	asdu := elements.NewASDUC_SE_NC_1(elements.COT_ACT, c.cfg.CommonAddress, address, value, qos)
+	if c.cfg.TimeTaggedCommands {
+		asdu = elements.NewASDUC_SE_TC_1(elements.COT_ACT, c.cfg.CommonAddress, address, value, qos, c.now())
+	}
+	return c.command(ctx, asdu)
+}
+
+// LoadParameter 下装测量值参数（P_ME_NA_1、P_ME_NB_1、P_ME_NC_1），等待从站激活确认
+//
+// 参数通常在 ActivateParameter 激活后才生效，是否立即运行由 QPM.POP 决定。
+func (c *Client) LoadParameter(ctx context.Context, p elements.Parameter) error {
+	switch p.TypeID() {
+	case elements.P_ME_NA_1, elements.P_ME_NB_1, elements.P_ME_NC_1:
+	default:
+		return &CommandError{TypeID: p.TypeID(), Err: ErrInvalidCommand}
+	}
+	asdu := elements.ASDU{
+		DUI: elements.DUI{
+			TypeIdentification:         p.TypeID(),
+			VariableStructureQualifier: byte(elements.NewVSQ(false, 1)),
+			COT:                        elements.COT{Cause: elements.COT_ACT},
+			PublicAddressLow:           byte(c.cfg.CommonAddress),
+			PublicAddressHig:           byte(c.cfg.CommonAddress >> 8),
+		},
+		MessageBody: p,
+	}
+	return c.command(ctx, asdu)
+}
+
+// ActivateParameter 发送参数激活命令（P_AC_NA_1），activate为false时停止激活，等待从站确认
+//
+// qpa为 elements.QPA_GENERAL 时address应为0。
+func (c *Client) ActivateParameter(ctx context.Context, address uint32, qpa byte, activate bool) error {
+	cause := elements.COT_ACT
+	if !activate {
+		cause = elements.COT_DEACT
+	}
+	asdu := elements.NewASDUP_AC_NA_1(cause, c.cfg.CommonAddress, address, qpa)
+	return c.command(ctx, asdu)
+}
+
+// TestCommand 发送测试命令（C_TS_NA_1），等待从站激活确认
+func (c *Client) TestCommand(ctx context.Context) error {
+	asdu := elements.NewASDUC_TS_NA_1(elements.COT_ACT, c.cfg.CommonAddress, 0, elements.FBP_TEST)
+	return c.command(ctx, asdu)
+}
+
+// TestCommandWithTime 以当前时间及自增的测试顺序计数器发送带时标的测试命令（C_TS_TA_1），等待从站激活确认
+func (c *Client) TestCommandWithTime(ctx context.Context) error {
+	tsc := uint16(c.tsc.Add(1))
+	asdu := elements.NewASDUC_TS_TA_1(elements.COT_ACT, c.cfg.CommonAddress, 0, tsc, c.now())
+	return c.command(ctx, asdu)
+}
+
+// ResetProcess 发送复位进程命令（C_RP_NA_1），等待从站激活确认
+//
+// qrp为 elements.QRP_GENERAL 时从站复位后一般会发送初始化结束，见 Config.ReinterrogateOnInit。
+func (c *Client) ResetProcess(ctx context.Context, qrp byte) error {
+	asdu := elements.NewASDUC_RP_NA_1(elements.COT_ACT, c.cfg.CommonAddress, 0, qrp)
+	return c.command(ctx, asdu)
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	elements "github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+var (
+	// ErrNegativeConfirm 从站否定确认（传送原因P/N位置位）
+	ErrNegativeConfirm = errors.New("否定确认")
+	// ErrTimeout 等待确认或激活终止超时
+	ErrTimeout = errors.New("等待确认超时")
+	// ErrClosed 客户端已关闭
+	ErrClosed = errors.New("客户端已关闭")
+	// ErrNotRunning 客户端未运行
+	ErrNotRunning = errors.New("客户端未运行")
+	// ErrCommandPending 相同的命令正在等待确认
+	ErrCommandPending = errors.New("命令正在等待确认")
+	// ErrInvalidCommand 命令不是只含一个信息对象的ASDU
+	ErrInvalidCommand = errors.New("命令非法")
+	// ErrChecksum 文件传输校验和错误
+	ErrChecksum = errors.New("校验和错误")
+)
+
+// CommandError 命令执行异常，Err为 ErrNegativeConfirm 或 ErrTimeout 等
+type CommandError struct {
+	TypeID byte         // 命令类型标识
+	COT    elements.COT // 从站响应的传送原因，超时时为零值
+	Err    error        // 具体异常
+}
+
+func (e *CommandError) Error() string {
+	if e.COT == (elements.COT{}) {
+		return fmt.Sprintf("命令[%d]执行异常: %v", e.TypeID, e.Err)
+	}
+	return fmt.Sprintf("命令[%d]执行异常，传送原因[%v]: %v", e.TypeID, e.COT, e.Err)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
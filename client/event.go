@@ -0,0 +1,137 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+// Event 通过 Config.Events 输出的事件，具体类型为 *ProtectionEvent 等
+type Event interface {
+	event()
+}
+
+// ProtectionEvent 继电保护设备事件（M_EP_TD_1、M_EP_TE_1、M_EP_TF_1）
+type ProtectionEvent struct {
+	CommonAddress uint16        // 应用服务数据单元公共地址
+	Address       uint32        // 信息对象地址
+	Tag           string        // 点表中的标签，未配置时为十六进制信息对象地址
+	TypeID        byte          // 类型标识
+	Time          time.Time     // 事件发生时间
+	Elapsed       time.Duration // M_EP_TD_1为动作时间，M_EP_TE_1为继电器持续时间，M_EP_TF_1为继电器动作时间
+	Invalid       bool          // 事件时标无效
+
+	// Object 原始信息对象，可断言为 elements.MessageElement_38_SQ_0_Ele、
+	// elements.MessageElement_39_SQ_0_Ele 或 elements.MessageElement_40_SQ_0_Ele
+	// 以获取SEP、SPE、OCI及QDP
+	Object elements.ProtectionObject
+}
+
+func (*ProtectionEvent) event() {}
+
+// PackedPointEvent 带变位检出的成组单点信息（M_PS_NA_1）中的单个遥信
+//
+// 每个信息对象产生16个事件，Changed表示上次报告后遥信至少变位一次，
+// 即使当前状态与上次报告相同也可据此记录期间发生的变位。
+type PackedPointEvent struct {
+	CommonAddress uint16       // 应用服务数据单元公共地址
+	Address       uint32       // 信息对象地址
+	Index         int          // 遥信在信息对象中的序号 0-15
+	Tag           string       // 点表中信息对象的标签（未配置时为十六进制信息对象地址）加".序号"
+	Value         bool         // 当前状态，点表配置取反时为取反后的状态
+	Changed       bool         // 上次报告后是否变位
+	QDS           elements.QDS // 信息对象的品质描述词
+}
+
+func (*PackedPointEvent) event() {}
+
+// StationRestartEvent 从站初始化结束（M_EI_NA_1）
+//
+// 从站重启后数据可能与主站不一致，应重新总召唤，
+// 可通过 Config.ReinterrogateOnInit 及 Config.ClockSyncOnInit 自动执行。
+type StationRestartEvent struct {
+	CommonAddress uint16       // 应用服务数据单元公共地址
+	COI           elements.COI // 初始化原因
+}
+
+func (*StationRestartEvent) event() {}
+
+// toEvents 将继电保护设备事件、成组单点信息及初始化结束ASDU转换为事件，其他类型返回nil
+func toEvents(asdu elements.ASDU, points *PointMap, loc *time.Location) []Event {
+	switch asdu.DUI.TypeIdentification {
+	case elements.M_EP_TD_1, elements.M_EP_TE_1, elements.M_EP_TF_1:
+		return protectionEvents(asdu, points, loc)
+	case elements.M_PS_NA_1:
+		return packedPointEvents(asdu, points)
+	case elements.M_EI_NA_1:
+		e, ok := asdu.MessageBody.(elements.MessageElement_70)
+		if !ok {
+			return nil
+		}
+		return []Event{&StationRestartEvent{CommonAddress: asdu.DUI.CommonAddress(), COI: e.COI}}
+	default:
+		return nil
+	}
+}
+
+func packedPointEvents(asdu elements.ASDU, points *PointMap) []Event {
+	var events []Event
+	for _, o := range asdu.Objects() {
+		e, ok := o.(elements.MessageElement_20_SQ_0_Ele)
+		if !ok {
+			continue
+		}
+		tag := points.tag(asdu.DUI.CommonAddress(), o.IOA(), o.TypeID())
+		point, _ := points.Lookup(asdu.DUI.CommonAddress(), o.IOA(), o.TypeID())
+		for _, p := range e.Core.SCD.Points() {
+			events = append(events, &PackedPointEvent{
+				CommonAddress: asdu.DUI.CommonAddress(),
+				Address:       o.IOA(),
+				Index:         p.Index,
+				Tag:           fmt.Sprintf("%s.%d", tag, p.Index),
+				Value:         p.Value != point.Invert,
+				Changed:       p.Changed,
+				QDS:           e.Core.QDS,
+			})
+		}
+	}
+	return events
+}
+
+func protectionEvents(asdu elements.ASDU, points *PointMap, loc *time.Location) []Event {
+	var events []Event
+	for _, o := range asdu.Objects() {
+		p, ok := o.(elements.ProtectionObject)
+		if !ok {
+			continue
+		}
+		t := p.EventTime()
+		events = append(events, &ProtectionEvent{
+			CommonAddress: asdu.DUI.CommonAddress(),
+			Address:       o.IOA(),
+			Tag:           points.tag(asdu.DUI.CommonAddress(), o.IOA(), o.TypeID()),
+			TypeID:        o.TypeID(),
+			Time:          t.Time(loc),
+			Elapsed:       p.Elapsed().Duration(),
+			Invalid:       t.IV,
+			Object:        p,
+		})
+	}
+	return events
+}
+
+// emit 输出事件，未配置 Config.Events 时丢弃
+func (c *Client) emit(events []Event) {
+	if c.cfg.Events == nil {
+		return
+	}
+	for _, e := range events {
+		select {
+		case c.cfg.Events <- e:
+			c.Log.Debugf("获得事件: %+v", e)
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
@@ -1,27 +1,38 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/wangxianzhuo/iec104/client"
+	"github.com/wangxianzhuo/iec104/logger/logrusadapter"
 )
 
 func main() {
-	address := "127.0.0.1:2404"
 	outChan := make(chan map[string]float32)
-	testInterval := 5 * time.Second
-	c, _, err := client.New(address, outChan, logrus.WithField("client", "iec104"))
+	go func() {
+		for d := range outChan {
+			fmt.Println(d)
+		}
+	}()
+
+	ctx := context.Background()
+	c, err := client.Dial(ctx, client.Config{
+		Address:         "127.0.0.1:2404",
+		TestInterval:    5 * time.Second,
+		ConnectDeadline: 1 * time.Minute,
+		Output:          outChan,
+		Log:             logrusadapter.New(logrus.WithField("client", "iec104")),
+	})
 	if err != nil {
 		panic(err)
 	}
-	client.SetConnectDeadLine(1 * time.Minute)
-
-	go c.Start(testInterval)
 	defer c.Close()
 
-	for d := range outChan {
-		fmt.Println(d)
+	err = c.Run(ctx)
+	if err != nil {
+		panic(err)
 	}
 }
@@ -0,0 +1,240 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+// FileInfo 从站目录中的文件
+type FileInfo struct {
+	Address uint32       // 信息对象地址，读取文件时使用
+	Name    uint16       // 文件名称（NOF），如 elements.NOF_DISTURBANCE
+	Length  int          // 文件长度
+	Status  elements.SOF // 文件状态
+	Time    time.Time    // 文件的创建时间
+}
+
+// files 文件传输，同一时刻只进行一个文件操作
+type files struct {
+	mux  sync.Mutex // 文件操作锁
+	cmux sync.Mutex // 保护ch及done
+	ch   chan elements.ASDU
+	done chan struct{} // 文件操作结束时关闭
+}
+
+// isFileType 类型是否属于文件传输
+func isFileType(t byte) bool {
+	return t >= elements.F_FR_NA_1 && t <= elements.F_DR_TA_1
+}
+
+// begin 开始文件操作，返回接收从站文件传输报文的通道
+func (f *files) begin() chan elements.ASDU {
+	f.mux.Lock()
+	ch := make(chan elements.ASDU, 16)
+	f.cmux.Lock()
+	f.ch, f.done = ch, make(chan struct{})
+	f.cmux.Unlock()
+	return ch
+}
+
+// end 结束文件操作，丢弃之后收到的文件传输报文
+func (f *files) end() {
+	f.cmux.Lock()
+	close(f.done)
+	f.ch, f.done = nil, nil
+	f.cmux.Unlock()
+	f.mux.Unlock()
+}
+
+// deliver 将文件传输报文交给进行中的文件操作，返回报文是否属于文件传输
+//
+// 文件操作提前结束时不再等待，丢弃报文。
+func (f *files) deliver(ctx context.Context, asdu elements.ASDU) bool {
+	if !isFileType(asdu.DUI.TypeIdentification) {
+		return false
+	}
+	f.cmux.Lock()
+	ch, done := f.ch, f.done
+	f.cmux.Unlock()
+	if ch == nil {
+		return true
+	}
+	select {
+	case ch <- asdu:
+	case <-done:
+	case <-ctx.Done():
+	}
+	return true
+}
+
+// await 等待从站类型为typeIDs之一的文件传输报文，超时时间为 Config.Timeout
+func (c *Client) await(ctx context.Context, ch chan elements.ASDU, typeIDs ...byte) (elements.ASDU, error) {
+	timer := time.NewTimer(c.cfg.Timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case asdu := <-ch:
+			cot := asdu.DUI.COT
+			if cot.Negative || cot.Cause >= elements.COT_UNKNOWN_TYPE {
+				return asdu, &CommandError{TypeID: asdu.DUI.TypeIdentification, COT: cot, Err: ErrNegativeConfirm}
+			}
+			for _, t := range typeIDs {
+				if asdu.DUI.TypeIdentification == t {
+					return asdu, nil
+				}
+			}
+			c.Log.Warnf("文件传输中收到非预期的报文[%d]", asdu.DUI.TypeIdentification)
+		case <-timer.C:
+			return elements.ASDU{}, &CommandError{TypeID: typeIDs[0], Err: ErrTimeout}
+		case <-ctx.Done():
+			return elements.ASDU{}, ctx.Err()
+		case <-c.ctx.Done():
+			return elements.ASDU{}, ErrClosed
+		}
+	}
+}
+
+// checkRunning 检查客户端是否已运行且未关闭
+func (c *Client) checkRunning() error {
+	c.mux.Lock()
+	running := c.running
+	c.mux.Unlock()
+	if !running {
+		return ErrNotRunning
+	}
+	if c.ctx.Err() != nil {
+		return ErrClosed
+	}
+	return nil
+}
+
+// Directory 召唤目录（F_SC_NA_1），返回从站的文件列表
+func (c *Client) Directory(ctx context.Context) ([]FileInfo, error) {
+	if err := c.checkRunning(); err != nil {
+		return nil, err
+	}
+	ch := c.xfer.begin()
+	defer c.xfer.end()
+
+	asdu := elements.NewASDUF_SC_NA_1(elements.COT_REQ, c.cfg.CommonAddress, 0, 0, 0, elements.FileQualifier{})
+	if err := c.sendI(asdu); err != nil {
+		return nil, err
+	}
+	var infos []FileInfo
+	for {
+		resp, err := c.await(ctx, ch, elements.F_DR_TA_1)
+		if err != nil {
+			return nil, err
+		}
+		last := false
+		for _, o := range resp.Objects() {
+			e, ok := o.(elements.MessageElement_126_SQ_0_Ele)
+			if !ok {
+				continue
+			}
+			infos = append(infos, FileInfo{
+				Address: e.Address,
+				Name:    e.Core.NOF,
+				Length:  int(e.Core.LOF),
+				Status:  e.Core.SOF,
+				Time:    e.Core.Time.Time(c.cfg.Location),
+			})
+			last = last || e.Core.SOF.LFD
+		}
+		if last {
+			return infos, nil
+		}
+	}
+}
+
+// ReadFile 选择并召唤文件，逐节读取并校验校验和，返回文件内容
+//
+// 节或文件校验和错误时向从站发送否定认可，返回的异常满足 errors.Is(err, ErrChecksum)；
+// 选择文件后因异常、超时或ctx结束而中止时向从站发送停止激活文件。
+func (c *Client) ReadFile(ctx context.Context, address uint32, name uint16) (_ []byte, err error) {
+	if err := c.checkRunning(); err != nil {
+		return nil, err
+	}
+	ch := c.xfer.begin()
+	defer c.xfer.end()
+
+	ca := c.cfg.CommonAddress
+	scq := func(code byte, nos byte) error {
+		return c.sendI(elements.NewASDUF_SC_NA_1(elements.COT_FILE, ca, address, name, nos, elements.FileQualifier{Code: code}))
+	}
+	afq := func(code, errCode byte, nos byte) error {
+		return c.sendI(elements.NewASDUF_AF_NA_1(elements.COT_FILE, ca, address, name, nos, elements.FileQualifier{Code: code, Err: errCode}))
+	}
+
+	if err := scq(elements.SCQ_SELECT_FILE, 0); err != nil {
+		return nil, err
+	}
+	resp, err := c.await(ctx, ch, elements.F_FR_NA_1)
+	if err != nil {
+		return nil, err
+	}
+	ready := resp.MessageBody.(elements.MessageElement_120)
+	if ready.FRQ&elements.FRQ_NEGATIVE != 0 {
+		return nil, &CommandError{TypeID: elements.F_SC_NA_1, COT: resp.DUI.COT, Err: ErrNegativeConfirm}
+	}
+	defer func() {
+		if err != nil {
+			scq(elements.SCQ_DEACTIVATE_FILE, 0)
+		}
+	}()
+	if err := scq(elements.SCQ_CALL_FILE, 0); err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, ready.LOF)
+	for {
+		resp, err := c.await(ctx, ch, elements.F_SR_NA_1, elements.F_LS_NA_1)
+		if err != nil {
+			return nil, err
+		}
+		if last, ok := resp.MessageBody.(elements.MessageElement_123); ok {
+			// 最后的节
+			if last.CHS != elements.Checksum(data) {
+				afq(elements.AFQ_FILE_NACK, elements.FILE_ERR_CHECKSUM, 0)
+				return nil, fmt.Errorf("文件[%X]%w", address, ErrChecksum)
+			}
+			if err := afq(elements.AFQ_FILE_ACK, elements.FILE_ERR_NONE, 0); err != nil {
+				return nil, err
+			}
+			return data, nil
+		}
+
+		section := resp.MessageBody.(elements.MessageElement_121)
+		if section.SRQ&elements.SRQ_NOT_READY != 0 {
+			return nil, fmt.Errorf("文件[%X]节[%d]未准备就绪", address, section.NOS)
+		}
+		if err := scq(elements.SCQ_CALL_SECTION, section.NOS); err != nil {
+			return nil, err
+		}
+		start := len(data)
+		for {
+			resp, err := c.await(ctx, ch, elements.F_SG_NA_1, elements.F_LS_NA_1)
+			if err != nil {
+				return nil, err
+			}
+			if seg, ok := resp.MessageBody.(elements.MessageElement_125); ok {
+				data = append(data, seg.Segment...)
+				continue
+			}
+			// 最后的段
+			last := resp.MessageBody.(elements.MessageElement_123)
+			if last.CHS != elements.Checksum(data[start:]) {
+				afq(elements.AFQ_SECTION_NACK, elements.FILE_ERR_CHECKSUM, section.NOS)
+				return nil, fmt.Errorf("文件[%X]节[%d]%w", address, section.NOS, ErrChecksum)
+			}
+			if err := afq(elements.AFQ_SECTION_ACK, elements.FILE_ERR_NONE, section.NOS); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package client
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+// PointKey 点位键，由公共地址、信息对象地址和类型标识组成
+type PointKey struct {
+	CommonAddress uint16 // 应用服务数据单元公共地址
+	IOA           uint32 // 信息对象地址
+	TypeID        byte   // 类型标识，0表示匹配任意类型
+}
+
+// Point 点位定义，将原始值转换为带工程单位的标签值
+type Point struct {
+	Tag    string  // 标签名
+	Unit   string  // 工程单位
+	Scale  float32 // 线性变换系数，工程值 = 原始值 * Scale + Offset
+	Offset float32 // 线性变换偏移
+	Invert bool    // 遥信取反，仅用于成组单点信息（M_PS_NA_1）点位
+}
+
+// Apply 将测量值原始值转换为工程值
+func (p Point) Apply(v float32) float32 {
+	return v*p.Scale + p.Offset
+}
+
+// PointConfig 点位配置，可由配置文件（如JSON）反序列化得到
+type PointConfig struct {
+	CommonAddress uint16  `json:"common_address"`
+	IOA           uint32  `json:"ioa"`
+	TypeID        byte    `json:"type"`
+	Tag           string  `json:"tag"`
+	Unit          string  `json:"unit"`
+	Scale         float32 `json:"scale"` // 为0时按1处理
+	Offset        float32 `json:"offset"`
+	Invert        bool    `json:"invert"`
+}
+
+// PointMap 点表，将(公共地址, 信息对象地址, 类型标识)映射为标签
+type PointMap struct {
+	points map[PointKey]Point
+}
+
+// NewPointMap 根据点位配置创建点表
+func NewPointMap(configs []PointConfig) (*PointMap, error) {
+	m := &PointMap{
+		points: make(map[PointKey]Point),
+	}
+	for _, cfg := range configs {
+		err := m.Add(cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Add 添加点位，标签名为空、点位重复或非遥信点位配置取反时返回异常
+func (m *PointMap) Add(cfg PointConfig) error {
+	if cfg.Tag == "" {
+		return fmt.Errorf("点位[%d/%d/%d]标签名为空", cfg.CommonAddress, cfg.IOA, cfg.TypeID)
+	}
+	if cfg.Invert && cfg.TypeID != elements.M_PS_NA_1 {
+		return fmt.Errorf("点位[%d/%d/%d]不是成组单点信息，不能取反", cfg.CommonAddress, cfg.IOA, cfg.TypeID)
+	}
+	key := PointKey{
+		CommonAddress: cfg.CommonAddress,
+		IOA:           cfg.IOA,
+		TypeID:        cfg.TypeID,
+	}
+	if p, ok := m.points[key]; ok {
+		return fmt.Errorf("点位[%d/%d/%d]重复定义: [%s] [%s]", cfg.CommonAddress, cfg.IOA, cfg.TypeID, p.Tag, cfg.Tag)
+	}
+	scale := cfg.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	m.points[key] = Point{
+		Tag:    cfg.Tag,
+		Unit:   cfg.Unit,
+		Scale:  scale,
+		Offset: cfg.Offset,
+		Invert: cfg.Invert,
+	}
+	return nil
+}
+
+// Lookup 查找点位，优先精确匹配类型标识，其次匹配类型标识为0的点位
+func (m *PointMap) Lookup(commonAddress uint16, ioa uint32, typeID byte) (Point, bool) {
+	if m == nil {
+		return Point{}, false
+	}
+	p, ok := m.points[PointKey{CommonAddress: commonAddress, IOA: ioa, TypeID: typeID}]
+	if ok {
+		return p, true
+	}
+	p, ok = m.points[PointKey{CommonAddress: commonAddress, IOA: ioa}]
+	return p, ok
+}
+
+// tag 点位标签，未配置时为十六进制信息对象地址
+func (m *PointMap) tag(commonAddress uint16, ioa uint32, typeID byte) string {
+	if p, ok := m.Lookup(commonAddress, ioa, typeID); ok {
+		return p.Tag
+	}
+	return fmt.Sprintf("%X", ioa)
+}
+
+// pointColumns 点表CSV的列名
+var pointColumns = []string{"common_address", "ioa", "type", "tag", "unit", "scale", "offset", "invert"}
+
+// LoadPointMapCSV 从CSV加载点表
+//
+// 第一行为表头，按列名对应字段，列名为: common_address,ioa,type,tag,unit,scale,offset,invert，
+// 顺序不限，其中common_address、ioa、tag必须存在；地址支持十进制或0x前缀的十六进制。
+func LoadPointMapCSV(r io.Reader) (*PointMap, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("读取点表CSV异常: %v", err)
+	}
+	if len(records) < 1 {
+		return NewPointMap(nil)
+	}
+	columns, err := parsePointHeader(records[0])
+	if err != nil {
+		return nil, fmt.Errorf("点表CSV表头异常: %v", err)
+	}
+
+	var configs []PointConfig
+	for i, record := range records[1:] {
+		cfg, err := parsePointRecord(record, columns)
+		if err != nil {
+			return nil, fmt.Errorf("点表CSV第%d行异常: %v", i+2, err)
+		}
+		configs = append(configs, cfg)
+	}
+	return NewPointMap(configs)
+}
+
+// parsePointHeader 解析表头，返回列名到列序号的映射
+func parsePointHeader(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		known := false
+		for _, c := range pointColumns {
+			known = known || c == name
+		}
+		if !known {
+			return nil, fmt.Errorf("列名[%s]未知，应为%v之一", name, pointColumns)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("列名[%s]重复", name)
+		}
+		columns[name] = i
+	}
+	for _, name := range []string{"common_address", "ioa", "tag"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("缺少列[%s]", name)
+		}
+	}
+	return columns, nil
+}
+
+func parsePointRecord(record []string, columns map[string]int) (PointConfig, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var cfg PointConfig
+	ca, err := strconv.ParseUint(field("common_address"), 0, 16)
+	if err != nil {
+		return PointConfig{}, fmt.Errorf("公共地址[%s]非法: %v", field("common_address"), err)
+	}
+	cfg.CommonAddress = uint16(ca)
+	ioa, err := strconv.ParseUint(field("ioa"), 0, 24)
+	if err != nil {
+		return PointConfig{}, fmt.Errorf("信息对象地址[%s]非法: %v", field("ioa"), err)
+	}
+	cfg.IOA = uint32(ioa)
+	if field("type") != "" {
+		t, err := strconv.ParseUint(field("type"), 0, 8)
+		if err != nil {
+			return PointConfig{}, fmt.Errorf("类型标识[%s]非法: %v", field("type"), err)
+		}
+		cfg.TypeID = byte(t)
+	}
+	cfg.Tag = field("tag")
+	cfg.Unit = field("unit")
+	if field("scale") != "" {
+		scale, err := strconv.ParseFloat(field("scale"), 32)
+		if err != nil {
+			return PointConfig{}, fmt.Errorf("系数[%s]非法: %v", field("scale"), err)
+		}
+		cfg.Scale = float32(scale)
+	}
+	if field("offset") != "" {
+		offset, err := strconv.ParseFloat(field("offset"), 32)
+		if err != nil {
+			return PointConfig{}, fmt.Errorf("偏移[%s]非法: %v", field("offset"), err)
+		}
+		cfg.Offset = float32(offset)
+	}
+	if field("invert") != "" {
+		invert, err := strconv.ParseBool(field("invert"))
+		if err != nil {
+			return PointConfig{}, fmt.Errorf("取反标志[%s]非法: %v", field("invert"), err)
+		}
+		cfg.Invert = invert
+	}
+	return cfg, nil
+}
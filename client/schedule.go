@@ -0,0 +1,206 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+const (
+	defaultScheduleTimeout = 30 * time.Second
+)
+
+// Schedule 周期召唤计划
+//
+// 例如每15分钟一次总召唤:
+//
+//	Schedule{Name: "GI", Command: elements.C_IC_NA_1, Qualifier: elements.QOI_GLOBAL_CALL, Interval: 15 * time.Minute}
+//
+// 每小时整点冻结并读取计数量（两个计划按配置顺序依次执行）:
+//
+//	Schedule{Name: "freeze", Command: elements.C_CI_NA_1, Qualifier: elements.QCC_RQT_GENERAL | elements.QCC_FRZ_FREEZE, Interval: time.Hour, Align: true}
+//	Schedule{Name: "read", Command: elements.C_CI_NA_1, Qualifier: elements.QCC_RQT_GENERAL | elements.QCC_FRZ_READ, Interval: time.Hour, Align: true}
+type Schedule struct {
+	Name      string        // 计划名称，用于日志及超时报告
+	Command   byte          // 命令类型标识，elements.C_IC_NA_1 或 elements.C_CI_NA_1
+	Qualifier byte          // 召唤限定词QOI或计数量召唤命令限定词QCC
+	Interval  time.Duration // 执行周期
+	Align     bool          // 是否按执行周期对齐到整点时刻（以UTC零点为基准），如每小时整点执行
+	Timeout   time.Duration // 等待激活终止的超时时间，0表示30秒
+}
+
+func (s Schedule) next(now time.Time) time.Time {
+	if s.Align {
+		return now.Truncate(s.Interval).Add(s.Interval)
+	}
+	return now.Add(s.Interval)
+}
+
+func (s Schedule) timeout() time.Duration {
+	if s.Timeout <= 0 {
+		return defaultScheduleTimeout
+	}
+	return s.Timeout
+}
+
+func (s Schedule) validate() error {
+	if s.Interval <= 0 {
+		return fmt.Errorf("召唤计划[%s]执行周期[%v]非法", s.Name, s.Interval)
+	}
+	switch s.Command {
+	case elements.C_IC_NA_1, elements.C_CI_NA_1:
+		return nil
+	default:
+		return fmt.Errorf("召唤计划[%s]命令类型[%d]不支持", s.Name, s.Command)
+	}
+}
+
+// scheduler 召唤计划调度器，同一时刻只有一个召唤命令在执行
+type scheduler struct {
+	schedules []Schedule
+	mux       sync.Mutex
+	pending   byte // 正在执行的命令类型标识，0表示无
+	done      chan error
+}
+
+func newScheduler(schedules []Schedule) *scheduler {
+	return &scheduler{
+		schedules: schedules,
+		done:      make(chan error, 1),
+	}
+}
+
+// begin 登记正在执行的命令
+func (s *scheduler) begin(command byte) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.pending = command
+	select {
+	case <-s.done:
+	default:
+	}
+}
+
+// end 清除正在执行的命令
+func (s *scheduler) end() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.pending = 0
+}
+
+// confirm 处理召唤命令的确认及终止报文，返回报文是否属于召唤命令
+func (s *scheduler) confirm(asdu elements.ASDU) bool {
+	t := asdu.DUI.TypeIdentification
+	if t != elements.C_IC_NA_1 && t != elements.C_CI_NA_1 {
+		return false
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.pending != t {
+		return true
+	}
+
+	var result error
+	cot := asdu.DUI.COT
+	switch {
+	case cot.Negative:
+		result = &CommandError{TypeID: t, COT: cot, Err: ErrNegativeConfirm}
+	case cot.Cause == elements.COT_ACTTERM:
+		result = nil
+	default:
+		return true
+	}
+
+	s.pending = 0
+	select {
+	case s.done <- result:
+	default:
+	}
+	return true
+}
+
+func (c *Client) schedule() error {
+	c.Log.Infof("召唤计划调度启动，共%d个计划", len(c.sched.schedules))
+	now := time.Now()
+	nexts := make([]time.Time, len(c.sched.schedules))
+	for i, s := range c.sched.schedules {
+		nexts[i] = s.next(now)
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		earliest := nexts[0]
+		for _, n := range nexts[1:] {
+			if n.Before(earliest) {
+				earliest = n
+			}
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(time.Until(earliest))
+
+		select {
+		case <-c.ctx.Done():
+			c.Log.Infof("召唤计划调度停止")
+			return nil
+		case <-timer.C:
+		}
+
+		// 到期的计划按配置顺序依次执行；执行期间错过的周期只补执行一次
+		for i, s := range c.sched.schedules {
+			if time.Now().Before(nexts[i]) {
+				continue
+			}
+			c.runSchedule(s)
+			nexts[i] = s.next(time.Now())
+			if c.ctx.Err() != nil {
+				return nil
+			}
+		}
+	}
+}
+
+func (c *Client) runSchedule(s Schedule) {
+	var asdu elements.ASDU
+	switch s.Command {
+	case elements.C_IC_NA_1:
+		asdu = elements.NewASDUC_IC_NA_1(elements.COT_ACT, c.cfg.CommonAddress, s.Qualifier)
+	case elements.C_CI_NA_1:
+		asdu = elements.NewASDUC_CI_NA_1(elements.COT_ACT, c.cfg.CommonAddress, s.Qualifier)
+	}
+
+	c.Log.Debugf("执行召唤计划[%s]", s.Name)
+	c.sched.begin(s.Command)
+	defer c.sched.end()
+	err := c.sendI(asdu)
+	if err != nil {
+		c.Log.Errorf("召唤计划[%s]发送异常: %v", s.Name, err)
+		return
+	}
+
+	timer := time.NewTimer(s.timeout())
+	defer timer.Stop()
+	select {
+	case err := <-c.sched.done:
+		if err != nil {
+			c.Log.Errorf("召唤计划[%s]执行失败: %v", s.Name, err)
+			return
+		}
+		c.Log.Debugf("召唤计划[%s]执行完成", s.Name)
+	case <-timer.C:
+		err := &CommandError{TypeID: s.Command, Err: ErrTimeout}
+		c.Log.Errorf("召唤计划[%s]等待激活终止超时(%v): %v", s.Name, s.timeout(), err)
+		if c.cfg.OnScheduleTimeout != nil {
+			c.cfg.OnScheduleTimeout(s)
+		}
+	case <-c.ctx.Done():
+	}
+}
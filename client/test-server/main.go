@@ -7,8 +7,8 @@ import (
 	"sync"
 	"time"
 
-	"github.com/Sirupsen/logrus"
 	"github.com/wangxianzhuo/iec104"
+	"github.com/wangxianzhuo/iec104/logger"
 )
 
 func main() {
@@ -27,7 +27,7 @@ type Server struct {
 	outChan  chan map[string]float32
 	ctx      context.Context
 	cancel   context.CancelFunc
-	Log      *logrus.Entry
+	Log      logger.Logger
 	mux      *sync.Mutex
 	vs       int
 	vr       int
@@ -36,19 +36,18 @@ type Server struct {
 
 func (s Server) uFrameResp() {
 	defer s.cancel()
-	s.Log.Info("U帧响应线程启动")
+	s.Log.Infof("U帧响应线程启动")
 	for {
 		select {
 		case <-s.ctx.Done():
-			s.Log.Info("U帧接收线程停止")
+			s.Log.Infof("U帧接收线程停止")
 		case apdu := <-s.ctrChan:
 			s.Log.Debugf("接收U帧[%v]", apdu)
 			uFrame := apdu.CtrFrame.(iec104.UFrame)
 			if uFrame.STARTDT_ACT {
 				uFrame.STARTDT_ACT = false
 				uFrame.STARTDT_CON = true
-				apci, _ := iec104.NewAPCI(iec104.ApciLen, uFrame)
-				resp, _ := iec104.NewAPDU(apci, nil)
+				resp, _ := iec104.BuildAPDU(uFrame, nil)
 				_, err := s.conn.Write(resp.ConvertBytes())
 				if err != nil {
 					s.Log.Errorf("响应U帧[%v]异常: %v", apdu, err)
@@ -58,8 +57,7 @@ func (s Server) uFrameResp() {
 			} else if uFrame.STOPDT_ACT {
 				uFrame.STOPDT_ACT = false
 				uFrame.STOPDT_CON = true
-				apci, _ := iec104.NewAPCI(iec104.ApciLen, uFrame)
-				resp, _ := iec104.NewAPDU(apci, nil)
+				resp, _ := iec104.BuildAPDU(uFrame, nil)
 				_, err := s.conn.Write(resp.ConvertBytes())
 				if err != nil {
 					s.Log.Errorf("响应U帧[%v]异常: %v", apdu, err)
@@ -69,8 +67,7 @@ func (s Server) uFrameResp() {
 			} else if uFrame.TESTFR_ACT {
 				uFrame.TESTFR_ACT = false
 				uFrame.TESTFR_CON = true
-				apci, _ := iec104.NewAPCI(iec104.ApciLen, uFrame)
-				resp, _ := iec104.NewAPDU(apci, nil)
+				resp, _ := iec104.BuildAPDU(uFrame, nil)
 				_, err := s.conn.Write(resp.ConvertBytes())
 				if err != nil {
 					s.Log.Errorf("响应U帧[%v]异常: %v", apdu, err)
@@ -89,14 +86,14 @@ func (s Server) send() {
 }
 func (s Server) receive() {
 	defer s.cancel()
-	s.Log.Info("数据接收线程启动")
+	s.Log.Infof("数据接收线程启动")
 	for {
 		select {
 		case resp := <-s.dataChan:
-			switch f := resp.CtrFrame.(type) {
+			switch resp.CtrFrame.(type) {
 			case iec104.IFrame:
 			// 	// 处理I帧
-			// 	if resp.ASDU.DUI.TypeIdentification != elements.C_IC_NA_1 || resp.ASDU.DUI.Cause != elements.COT_ACTCON {
+			// 	if resp.ASDU.DUI.TypeIdentification != elements.C_IC_NA_1 || resp.ASDU.DUI.COT.Cause != elements.COT_ACTCON {
 			// 		// 非总召唤确认
 			// 		// 这里可能会有异常
 			// 		c.Log.Debugf("准备解析APDU[%v]浮点数", resp)
@@ -128,7 +125,7 @@ func (s Server) receive() {
 				s.Log.Warnf("未知类型APDU[%X]", resp.ConvertBytes())
 			}
 		case <-s.ctx.Done():
-			s.Log.Info("数据接收线程停止")
+			s.Log.Infof("数据接收线程停止")
 		}
 	}
 }
@@ -138,11 +135,11 @@ func (s Server) totalCallResp() {
 }
 func (s Server) read() {
 	defer s.cancel()
-	s.Log.Info("socket读线程启动")
+	s.Log.Infof("socket读线程启动")
 	for {
 		select {
 		case <-s.ctx.Done():
-			s.Log.Info("socket读线程停止")
+			s.Log.Infof("socket读线程停止")
 		default:
 		}
 		s.mux.Lock()
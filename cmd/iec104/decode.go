@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/wangxianzhuo/iec104"
+	elements "github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+const decodeUsage = `用法: iec104 decode [选项] [十六进制报文...]
+
+解析十六进制APDU报文并逐字段显示。报文依次来自参数及 -f 指定的文件，均未指定时读取标准输入。
+每个参数或每行为一段报文，可包含按长度域首尾相接的多个APDU；空格、逗号、冒号、连字符、方括号及0x前缀被忽略，
+空行及以#开头的行被跳过。
+
+选项:
+`
+
+// decodeOptions decode命令的选项
+type decodeOptions struct {
+	params elements.Params
+	json   bool
+	strict bool
+}
+
+// decode 执行decode命令，任一报文解析失败时返回1
+func decode(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("decode", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, decodeUsage)
+		fs.PrintDefaults()
+	}
+	causeSize := fs.Int("cause-size", 2, "传送原因长度，1或2")
+	caSize := fs.Int("ca-size", 2, "公共地址长度，1或2")
+	jsonOut := fs.Bool("json", false, "以JSON输出，每行一个APDU")
+	strict := fs.Bool("strict", false, "严格模式：长度域、信息对象数目须与报文长度一致，遇到异常立即退出")
+	file := fs.String("f", "", "报文文件，每行一段报文，-表示标准输入")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	opts := decodeOptions{
+		params: elements.Params{CauseSize: *causeSize, CommonAddrSize: *caSize, Lenient: !*strict},
+		json:   *jsonOut,
+		strict: *strict,
+	}
+	if err := opts.params.Validate(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	inputs := fs.Args()
+	var reader io.Reader
+	switch {
+	case *file == "-":
+		reader = stdin
+	case *file != "":
+		f, err := os.Open(*file)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		defer f.Close()
+		reader = f
+	case len(inputs) == 0:
+		reader = stdin
+	}
+
+	failed := false
+	for _, input := range inputs {
+		if !opts.decodeLine(input, stdout, stderr) {
+			if opts.strict {
+				return 1
+			}
+			failed = true
+		}
+	}
+	if reader != nil {
+		scanner := bufio.NewScanner(reader)
+		for line := 1; scanner.Scan(); line++ {
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+			if !opts.decodeLine(text, stdout, stderr) {
+				fmt.Fprintf(stderr, "第%d行解析失败\n", line)
+				if opts.strict {
+					return 1
+				}
+				failed = true
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// decodeLine 解析一段报文中的所有APDU并输出，返回是否全部解析成功
+func (o decodeOptions) decodeLine(input string, stdout, stderr io.Writer) bool {
+	data, err := parseHex(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "报文[%s]不是合法的十六进制: %v\n", input, err)
+		return false
+	}
+	ok := true
+	for _, frame := range splitFrames(data) {
+		apdu, err := iec104.ParseAPDUWithParams(frame, o.params)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			if o.strict {
+				return false
+			}
+			ok = false
+			continue
+		}
+		if o.json {
+			b, err := json.Marshal(apdu)
+			if err != nil {
+				fmt.Fprintf(stderr, "报文[% X]编码JSON异常: %v\n", frame, err)
+				ok = false
+				continue
+			}
+			fmt.Fprintf(stdout, "%s\n", b)
+			continue
+		}
+		fmt.Fprintln(stdout, iec104.Describe(apdu))
+	}
+	return ok
+}
+
+// parseHex 解析十六进制报文，忽略分隔符、方括号及0x前缀
+func parseHex(s string) ([]byte, error) {
+	s = strings.NewReplacer("0x", "", "0X", "").Replace(s)
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', ',', ':', '-', '[', ']':
+			return -1
+		}
+		return r
+	}, s)
+	return hex.DecodeString(s)
+}
+
+// splitFrames 按长度域拆分首尾相接的多个APDU，剩余不足一帧的字节作为最后一帧
+func splitFrames(data []byte) [][]byte {
+	var frames [][]byte
+	for len(data) >= 2 && data[0] == 0x68 && len(data) > int(data[1])+2 {
+		n := int(data[1]) + 2
+		frames = append(frames, data[:n])
+		data = data[n:]
+	}
+	return append(frames, data)
+}
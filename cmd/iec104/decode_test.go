@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_decode(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	stdin := strings.NewReader("# 日志\n68 04 07 00 00 00\n\n0x68,0x0E,0x00,0x00,0x02,0x00,0x2E,0x01,0x06,0x00,0x01,0x00,0x05,0x00,0x00,0x82\n")
+	if code := run([]string{"decode"}, stdin, &stdout, &stderr); code != 0 {
+		t.Fatalf("退出码[%d]: %s", code, stderr.String())
+	}
+	for _, want := range []string{"UType: STARTDT_ACT", "TypeId: C_DC_NA_1 (46)", "DCO: 合 选择 QU[0]"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Fatalf("输出缺少[%s]:\n%s", want, stdout.String())
+		}
+	}
+
+	// 首尾相接的两帧，JSON输出
+	stdout.Reset()
+	if code := run([]string{"decode", "-json", "680401000600680443000000"}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("退出码[%d]: %s", code, stderr.String())
+	}
+	want := "{\"format\":\"S\",\"recv\":3}\n{\"format\":\"U\",\"function\":\"TESTFR_ACT\"}\n"
+	if stdout.String() != want {
+		t.Fatalf("JSON输出[%s]应为[%s]", stdout.String(), want)
+	}
+
+	// 信息对象数目多于实际报文：宽松模式仅解析完整的信息对象，严格模式报错
+	truncated := "681200000200" + "0D02030001000100000000C03F00"
+	stdout.Reset()
+	if code := run([]string{"decode", "-ca-size", "2", truncated}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("宽松模式退出码[%d]: %s", code, stderr.String())
+	}
+	stderr.Reset()
+	if code := run([]string{"decode", "-strict", truncated, "680407000000"}, nil, &stdout, &stderr); code != 1 || stderr.Len() == 0 {
+		t.Fatalf("严格模式退出码[%d]应为1", code)
+	}
+}
@@ -0,0 +1,42 @@
+// iec104 命令行工具
+//
+//	iec104 decode [选项] [十六进制报文...]
+//
+// decode 解析十六进制报文并逐字段显示，报文来自参数、文件（-f）或标准输入。
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `用法: iec104 <命令> [选项]
+
+命令:
+  decode    解析十六进制APDU报文并逐字段显示
+
+使用 "iec104 <命令> -h" 查看命令的选项。
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run 执行子命令，返回进程退出码
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	switch args[0] {
+	case "decode":
+		return decode(args[1:], stdin, stdout, stderr)
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "未知命令[%s]\n\n%s", args[0], usage)
+		return 2
+	}
+}
@@ -0,0 +1,68 @@
+package iec104
+
+import (
+	"fmt"
+	"strings"
+
+	elements "github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+// String 控制域及ASDU，如 I(N(S)=0 N(R)=1) M_ME_NC_1(13) SQ=0 数目[1] spont(3) 公共地址[1]: IOA[1] Value=1.5 QDS=-
+func (apdu APDU) String() string {
+	if apdu.CtrType != 0 {
+		return fmt.Sprint(apdu.CtrFrame)
+	}
+	return fmt.Sprintf("%v %v", apdu.CtrFrame, apdu.ASDU)
+}
+
+// Describe 以类似Wireshark的多行格式逐字段显示APDU，包括原始报文、控制域、数据单元标识符及各信息对象
+func Describe(apdu APDU) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "IEC 60870-5-104 APDU [% X]\n", apdu.ConvertBytes())
+	fmt.Fprintf(&b, "  APCI: Start 0x%02X, ApduLen %d\n", apdu.APCI.Start, apdu.APCI.ApduLen)
+	switch f := apdu.CtrFrame.(type) {
+	case IFrame:
+		fmt.Fprintf(&b, "    Type: I (0x00)\n    Tx: %d\n    Rx: %d\n", f.Send, f.Recv)
+	case SFrame:
+		fmt.Fprintf(&b, "    Type: S (0x01)\n    Rx: %d\n", f.Recv)
+	case UFrame:
+		fmt.Fprintf(&b, "    Type: U (0x03)\n    UType: %s\n", strings.TrimSuffix(strings.TrimPrefix(f.String(), "U("), ")"))
+	}
+	if apdu.CtrType != 0 {
+		return b.String()
+	}
+
+	asdu := apdu.ASDU
+	dui := asdu.DUI
+	name := elements.TypeName(dui.TypeIdentification)
+	if name == "" {
+		name = "未定义"
+	}
+	fmt.Fprintf(&b, "  ASDU: %s\n", dui)
+	fmt.Fprintf(&b, "    TypeId: %s (%d)\n", name, dui.TypeIdentification)
+	fmt.Fprintf(&b, "    SQ: %t\n", dui.VSQ().SQ())
+	fmt.Fprintf(&b, "    NumIx: %d\n", dui.VSQ().Number())
+	fmt.Fprintf(&b, "    CauseTx: %v\n", dui.COT.Cause)
+	fmt.Fprintf(&b, "    Negative: %t\n", dui.COT.Negative)
+	fmt.Fprintf(&b, "    Test: %t\n", dui.COT.Test)
+	if dui.CauseExtEnable {
+		fmt.Fprintf(&b, "    OA: %d\n", dui.COT.Originator)
+	}
+	fmt.Fprintf(&b, "    Addr: %d\n", dui.CommonAddress())
+
+	objs := asdu.Objects()
+	if objs == nil && asdu.MessageBody != nil {
+		fmt.Fprintf(&b, "    Body: [% X]\n", asdu.MessageBody.ConvertBytes())
+	}
+	for _, o := range objs {
+		fields := elements.Fields(o)
+		fmt.Fprintf(&b, "    IOA: %d\n", o.IOA())
+		for _, f := range fields {
+			if f.Name == "IOA" {
+				continue
+			}
+			fmt.Fprintf(&b, "      %s: %s\n", f.Name, f.Value)
+		}
+	}
+	return b.String()
+}
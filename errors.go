@@ -0,0 +1,27 @@
+package iec104
+
+import (
+	"errors"
+
+	elements "github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+var (
+	// ErrInvalidStartByte 启动字符不是68H
+	ErrInvalidStartByte = errors.New("启动字符非法")
+	// ErrInvalidLength 长度域不在4到253之间
+	ErrInvalidLength = errors.New("长度域非法")
+	// ErrUnknownFrameType 未知的控制域帧类型
+	ErrUnknownFrameType = errors.New("未知控制域帧类型")
+	// ErrTooLong APDU长度超过253字节
+	ErrTooLong = errors.New("APDU长度超过限制")
+	// ErrTruncated 报文长度不足
+	ErrTruncated = elements.ErrTruncated
+	// ErrLengthMismatch 报文长度与长度域不匹配
+	ErrLengthMismatch = elements.ErrLengthMismatch
+	// ErrUnknownTypeID 未知类型标识
+	ErrUnknownTypeID = elements.ErrUnknownTypeID
+)
+
+// ParseError 报文解析异常，Offset为相对于APDU起始位置的偏移
+type ParseError = elements.ParseError
@@ -0,0 +1,74 @@
+package iec104
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/wangxianzhuo/iec104/internal/frametest"
+	elements "github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+func Test_frames(t *testing.T) {
+	for _, frame := range frametest.Load(t, "testdata/frames.txt") {
+		apdu, err := ParseAPDU(frame)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := apdu.ConvertBytes(); !reflect.DeepEqual(got, frame) {
+			t.Fatalf("报文[%X]重新编码为[%X]", frame, got)
+		}
+	}
+}
+
+func FuzzParseAPDU(f *testing.F) {
+	for _, frame := range frametest.Load(f, "testdata/frames.txt") {
+		f.Add(frame)
+	}
+	f.Fuzz(func(t *testing.T, input []byte) {
+		// 宽松模式不能panic
+		ParseAPDUWithParams(input, elements.Params{Lenient: true})
+
+		apdu, err := ParseAPDU(input)
+		if err != nil {
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("解析异常[%v]不是ParseError", err)
+			}
+			return
+		}
+		again, err := ParseAPDU(apdu.ConvertBytes())
+		if err != nil {
+			t.Fatalf("报文[%X]重新编码为[%X]后解析异常: %v", input, apdu.ConvertBytes(), err)
+		}
+		// 浮点数可能为NaN，比较编码结果而不是解析结果
+		if !bytes.Equal(apdu.ConvertBytes(), again.ConvertBytes()) {
+			t.Fatalf("报文[%X]编解码结果不一致: %v != %v", input, apdu, again)
+		}
+	})
+}
+
+func FuzzParseCtr(f *testing.F) {
+	for _, frame := range frametest.Load(f, "testdata/frames.txt") {
+		f.Add(frame[2], frame[3], frame[4], frame[5])
+	}
+	f.Fuzz(func(t *testing.T, ctr1, ctr2, ctr3, ctr4 byte) {
+		apci := APCI{Start: 0x68, ApduLen: ApciLen, Ctr1: ctr1, Ctr2: ctr2, Ctr3: ctr3, Ctr4: ctr4}
+		frameType, frame, err := ParseCtr(apci)
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoded, err := NewAPCI(ApciLen, frame)
+		if err != nil {
+			t.Fatal(err)
+		}
+		againType, again, err := ParseCtr(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if frameType != againType || !reflect.DeepEqual(frame, again) {
+			t.Fatalf("控制域[%X %X %X %X]编解码结果不一致: %v != %v", ctr1, ctr2, ctr3, ctr4, frame, again)
+		}
+	})
+}
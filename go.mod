@@ -0,0 +1,7 @@
+module github.com/wangxianzhuo/iec104
+
+go 1.21
+
+require github.com/sirupsen/logrus v1.9.3
+
+require golang.org/x/sys v0.9.0 // indirect
@@ -0,0 +1,38 @@
+// Package frametest 测试用报文数据
+package frametest
+
+import (
+	"bufio"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Load 读取testdata中的十六进制报文，每行一帧，忽略空行及#开头的注释行
+func Load(tb testing.TB, path string) [][]byte {
+	tb.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer f.Close()
+
+	var frames [][]byte
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		frame, err := hex.DecodeString(line)
+		if err != nil {
+			tb.Fatalf("报文[%s]非法: %v", line, err)
+		}
+		frames = append(frames, frame)
+	}
+	if err := scanner.Err(); err != nil {
+		tb.Fatal(err)
+	}
+	return frames
+}
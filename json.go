@@ -0,0 +1,134 @@
+package iec104
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	elements "github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+// apduJSON APDU的JSON表示，ASDU的格式见 elements.ASDU.MarshalJSON
+//
+//	{"format": "I", "send": 0, "recv": 1, "asdu": {...}}
+//	{"format": "S", "recv": 1}
+//	{"format": "U", "function": "STARTDT_ACT"}
+type apduJSON struct {
+	Format   string         `json:"format"`
+	Send     *int16         `json:"send,omitempty"`
+	Recv     *int16         `json:"recv,omitempty"`
+	Function string         `json:"function,omitempty"` // U帧功能，多个功能以|分隔
+	ASDU     *elements.ASDU `json:"asdu,omitempty"`
+}
+
+// uFunctions U帧功能名称
+var uFunctions = []string{"STARTDT_ACT", "STARTDT_CON", "STOPDT_ACT", "STOPDT_CON", "TESTFR_ACT", "TESTFR_CON"}
+
+// MarshalJSON 编码为JSON，format为帧格式I、S或U
+func (apdu APDU) MarshalJSON() ([]byte, error) {
+	var v apduJSON
+	switch f := apdu.CtrFrame.(type) {
+	case IFrame:
+		v = apduJSON{Format: "I", Send: &f.Send, Recv: &f.Recv}
+		if apdu.ASDULen > 0 {
+			// 宽松模式下解析的I帧可能不带ASDU
+			v.ASDU = &apdu.ASDU
+		}
+	case SFrame:
+		v = apduJSON{Format: "S", Recv: &f.Recv}
+	case UFrame:
+		v = apduJSON{Format: "U", Function: strings.TrimSuffix(strings.TrimPrefix(f.String(), "U("), ")")}
+	default:
+		return nil, fmt.Errorf("%w[%T]", ErrUnknownFrameType, apdu.CtrFrame)
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON 由JSON还原APDU，结果与解析 ConvertBytes 编码的报文一致
+func (apdu *APDU) UnmarshalJSON(data []byte) error {
+	var v apduJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	var ctr interface{}
+	switch v.Format {
+	case "I":
+		var f IFrame
+		if v.Send != nil {
+			f.Send = *v.Send
+		}
+		if v.Recv != nil {
+			f.Recv = *v.Recv
+		}
+		ctr = f
+	case "S":
+		var f SFrame
+		if v.Recv != nil {
+			f.Recv = *v.Recv
+		}
+		ctr = f
+	case "U":
+		var f UFrame
+		for _, name := range strings.Split(v.Function, "|") {
+			switch name {
+			case "STARTDT_ACT":
+				f.STARTDT_ACT = true
+			case "STARTDT_CON":
+				f.STARTDT_CON = true
+			case "STOPDT_ACT":
+				f.STOPDT_ACT = true
+			case "STOPDT_CON":
+				f.STOPDT_CON = true
+			case "TESTFR_ACT":
+				f.TESTFR_ACT = true
+			case "TESTFR_CON":
+				f.TESTFR_CON = true
+			default:
+				return fmt.Errorf("U帧功能[%s]未知，应为%v之一", name, uFunctions)
+			}
+		}
+		ctr = f
+	default:
+		return fmt.Errorf("%w[%s]", ErrUnknownFrameType, v.Format)
+	}
+
+	p := paramsOf(v.ASDU)
+	var built APDU
+	if _, ok := ctr.(IFrame); ok && v.ASDU == nil {
+		// 与 MarshalJSON 对应，不带ASDU的I帧按宽松模式还原
+		apci, err := NewAPCI(ApciLen, ctr)
+		if err != nil {
+			return err
+		}
+		if built, err = NewAPDU(apci, nil); err != nil {
+			return err
+		}
+		p.Lenient = true
+	} else {
+		var err error
+		if built, err = BuildAPDU(ctr, v.ASDU); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseAPDUWithParams(built.ConvertBytes(), p)
+	if err != nil {
+		return err
+	}
+	*apdu = parsed
+	return nil
+}
+
+// paramsOf 与asdu的传送原因及公共地址长度一致的编解码参数
+func paramsOf(asdu *elements.ASDU) elements.Params {
+	p := elements.DefaultParams
+	if asdu == nil {
+		return p
+	}
+	if !asdu.DUI.CauseExtEnable {
+		p.CauseSize = 1
+	}
+	if !asdu.DUI.PublicAddressHigEnable {
+		p.CommonAddrSize = 1
+	}
+	return p
+}
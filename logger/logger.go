@@ -0,0 +1,88 @@
+// Package logger 定义客户端及从站使用的日志接口
+//
+// *logrus.Entry 和 *logrus.Logger 本身即实现了Logger接口，
+// 也可以使用 logger/logrusadapter 包进行转换；标准库 log/slog 使用 NewSlog 转换。
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// Logger 日志接口
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// Nop 不输出任何日志
+var Nop Logger = nop{}
+
+type nop struct{}
+
+func (nop) Debugf(format string, args ...interface{}) {}
+func (nop) Infof(format string, args ...interface{})  {}
+func (nop) Warnf(format string, args ...interface{})  {}
+func (nop) Errorf(format string, args ...interface{}) {}
+func (nop) IsDebugEnabled() bool                      { return false }
+
+// DebugEnabled 判断l是否输出调试日志，用于跳过构造开销较大的日志参数
+//
+// l实现 IsDebugEnabled() bool 时以其结果为准，否则认为输出调试日志。
+func DebugEnabled(l Logger) bool {
+	if d, ok := l.(interface{ IsDebugEnabled() bool }); ok {
+		return d.IsDebugEnabled()
+	}
+	return true
+}
+
+// OrNop l为nil时返回Nop
+func OrNop(l Logger) Logger {
+	if l == nil {
+		return Nop
+	}
+	return l
+}
+
+// NewSlog 将 *slog.Logger 转换为Logger，l为nil时使用 slog.Default()
+func NewSlog(l *slog.Logger) Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return slogLogger{l: l}
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+func (s slogLogger) log(level slog.Level, format string, args []interface{}) {
+	ctx := context.Background()
+	if !s.l.Enabled(ctx, level) {
+		return
+	}
+	s.l.Log(ctx, level, fmt.Sprintf(format, args...))
+}
+
+func (s slogLogger) IsDebugEnabled() bool {
+	return s.l.Enabled(context.Background(), slog.LevelDebug)
+}
+
+func (s slogLogger) Debugf(format string, args ...interface{}) {
+	s.log(slog.LevelDebug, format, args)
+}
+
+func (s slogLogger) Infof(format string, args ...interface{}) {
+	s.log(slog.LevelInfo, format, args)
+}
+
+func (s slogLogger) Warnf(format string, args ...interface{}) {
+	s.log(slog.LevelWarn, format, args)
+}
+
+func (s slogLogger) Errorf(format string, args ...interface{}) {
+	s.log(slog.LevelError, format, args)
+}
@@ -0,0 +1,24 @@
+// Package logrusadapter 将logrus日志转换为 logger.Logger
+package logrusadapter
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/wangxianzhuo/iec104/logger"
+)
+
+// New 将 *logrus.Entry 转换为 logger.Logger，entry为nil时使用logrus标准日志
+func New(entry *logrus.Entry) logger.Logger {
+	if entry == nil {
+		entry = logrus.NewEntry(logrus.StandardLogger())
+	}
+	return adapter{entry}
+}
+
+type adapter struct {
+	*logrus.Entry
+}
+
+// IsDebugEnabled 见 logger.DebugEnabled
+func (a adapter) IsDebugEnabled() bool {
+	return a.Logger.IsLevelEnabled(logrus.DebugLevel)
+}
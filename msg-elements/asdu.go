@@ -1,38 +1,143 @@
 package elements
 
+import (
+	"io"
+	"sync"
+)
+
 type ASDU struct {
 	DUI         DUI
 	MessageBody BytesConverter
 }
 
 func (asdu ASDU) ConvertBytes() []byte {
-	var dui []byte
-	dui = append(dui, asdu.DUI.TypeIdentification)
-	dui = append(dui, asdu.DUI.VariableStructureQualifier)
-	dui = append(dui, asdu.DUI.Cause)
-	if asdu.DUI.CauseExtEnable {
-		dui = append(dui, asdu.DUI.CauseExt)
+	return asdu.AppendTo(make([]byte, 0, asdu.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (asdu ASDU) AppendTo(dst []byte) []byte {
+	dst = asdu.DUI.AppendTo(dst)
+	if asdu.MessageBody == nil {
+		return dst
 	}
-	dui = append(dui, asdu.DUI.PublicAddressLow)
-	if asdu.DUI.PublicAddressHigEnable {
-		dui = append(dui, asdu.DUI.PublicAddressHig)
+	return asdu.MessageBody.AppendTo(dst)
+}
+
+// Size 编码长度
+func (asdu ASDU) Size() int {
+	if asdu.MessageBody == nil {
+		return asdu.DUI.Size()
 	}
+	return asdu.DUI.Size() + asdu.MessageBody.Size()
+}
 
-	return append(dui, asdu.MessageBody.ConvertBytes()...)
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (asdu ASDU) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, asdu)
 }
 
 // DUI 数据单元标识符
 type DUI struct {
 	TypeIdentification         byte // 类型标识 1-127，《DLT 634.5101-2002》 7.2.1.1
 	VariableStructureQualifier byte // 可变结构限定词，《DLT 634.5101-2002》 7.2.2.1
-	Cause                      byte // 传送原因，《DLT 634.5101-2002》 7.2.3.1
-	CauseExt                   byte // 源发站地址
-	CauseExtEnable             bool // 源发站地址s使能
+	COT                        COT  // 传送原因，《DLT 634.5101-2002》 7.2.3
+	CauseExtEnable             bool // 传送原因为2字节（带源发站地址）
 	PublicAddressLow           byte // 应用服务数据单元公共地址低8位
 	PublicAddressHig           byte // 应用服务数据单元公共地址高8位
 	PublicAddressHigEnable     bool // 应用服务数据单元公共地址高8位使能
 }
 
+// AppendTo 将编码结果追加到dst
+func (dui DUI) AppendTo(dst []byte) []byte {
+	dst = append(dst, dui.TypeIdentification, dui.VariableStructureQualifier, dui.COT.Byte())
+	if dui.CauseExtEnable {
+		dst = append(dst, dui.COT.Originator)
+	}
+	dst = append(dst, dui.PublicAddressLow)
+	if dui.PublicAddressHigEnable {
+		dst = append(dst, dui.PublicAddressHig)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (dui DUI) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, dui)
+}
+
+// Size 编码长度
+func (dui DUI) Size() int {
+	size := 4
+	if dui.CauseExtEnable {
+		size++
+	}
+	if dui.PublicAddressHigEnable {
+		size++
+	}
+	return size
+}
+
+// VSQ 可变结构限定词
+func (dui DUI) VSQ() VSQ {
+	return VSQ(dui.VariableStructureQualifier)
+}
+
+// CommonAddress 应用服务数据单元公共地址
+func (dui DUI) CommonAddress() uint16 {
+	if !dui.PublicAddressHigEnable {
+		return uint16(dui.PublicAddressLow)
+	}
+	return uint16(dui.PublicAddressLow) | uint16(dui.PublicAddressHig)<<8
+}
+
+// VSQ 可变结构限定词，《DLT 634.5101-2002》 7.2.2.1
+type VSQ byte
+
+// NewVSQ 创建可变结构限定词，number不超过 MaxObjects
+func NewVSQ(sq bool, number int) VSQ {
+	v := VSQ(number & 0x7F)
+	if sq {
+		v |= 0x80
+	}
+	return v
+}
+
+// SQ 信息对象是否为顺序排列（SQ=1），即只有第一个信息对象带地址
+func (v VSQ) SQ() bool {
+	return v&0x80 != 0
+}
+
+// Number 信息对象数目
+func (v VSQ) Number() int {
+	return int(v & 0x7F)
+}
+
+// BytesConverter 可编码的信息体
 type BytesConverter interface {
 	ConvertBytes() []byte
+	AppendTo(dst []byte) []byte // 将编码结果追加到dst，dst容量足够时不分配内存
+	Size() int                  // 编码长度
+	io.WriterTo
+}
+
+// appendIOA 追加3字节信息对象地址
+func appendIOA(dst []byte, address uint32) []byte {
+	return append(dst, byte(address), byte(address>>8), byte(address>>16))
+}
+
+// bufferPool 编码缓冲区，容量为最大APDU长度255字节
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, 255)
+		return &buf
+	},
+}
+
+// WriteTo 以缓冲池中的缓冲区编码v并写入w，编码不分配内存，用于实现 io.WriterTo
+func WriteTo[T interface{ AppendTo(dst []byte) []byte }](w io.Writer, v T) (int64, error) {
+	buf := bufferPool.Get().(*[]byte)
+	defer bufferPool.Put(buf)
+	*buf = v.AppendTo((*buf)[:0])
+	n, err := w.Write(*buf)
+	return int64(n), err
 }
@@ -1,10 +1,50 @@
 package elements
 
 const (
+	M_BO_NA_1 = 7
 	M_ME_NA_1 = 9
+	M_ME_NB_1 = 11
+	M_ME_TB_1 = 12
 	M_ME_NC_1 = 13
+	M_IT_NA_1 = 15
+	M_PS_NA_1 = 20
+	M_ME_ND_1 = 21
+	M_BO_TB_1 = 33
+	M_ME_TE_1 = 35
+	M_EP_TD_1 = 38
+	M_EP_TE_1 = 39
+	M_EP_TF_1 = 40
+	C_SC_NA_1 = 45
+	C_DC_NA_1 = 46
+	C_RC_NA_1 = 47
+	C_SE_NA_1 = 48
+	C_SE_NB_1 = 49
+	C_SE_NC_1 = 50
+	C_BO_NA_1 = 51
+	C_SC_TA_1 = 58
+	C_DC_TA_1 = 59
+	C_RC_TA_1 = 60
+	C_SE_TA_1 = 61
+	C_SE_TB_1 = 62
+	C_SE_TC_1 = 63
+	C_BO_TA_1 = 64
+	M_EI_NA_1 = 70
 	C_IC_NA_1 = 100
 	C_CI_NA_1 = 101
 	C_RD_NA_1 = 102
+	C_CS_NA_1 = 103
 	C_TS_NA_1 = 104
+	C_RP_NA_1 = 105
+	C_TS_TA_1 = 107
+	P_ME_NA_1 = 110
+	P_ME_NB_1 = 111
+	P_ME_NC_1 = 112
+	P_AC_NA_1 = 113
+	F_FR_NA_1 = 120
+	F_SR_NA_1 = 121
+	F_SC_NA_1 = 122
+	F_LS_NA_1 = 123
+	F_AF_NA_1 = 124
+	F_SG_NA_1 = 125
+	F_DR_TA_1 = 126
 )
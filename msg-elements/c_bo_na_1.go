@@ -0,0 +1,89 @@
+package elements
+
+import "io"
+
+// MessageElement_51 32比特串命令，《DLT 634.5101-2002》 7.3.2.7 51:C_BO_NA_1
+type MessageElement_51 struct {
+	Address uint32 // 信息对象地址
+	BSI     BSI    // 32比特串
+}
+
+func (e MessageElement_51) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_51) AppendTo(dst []byte) []byte {
+	return e.BSI.AppendTo(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_51) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] BSI=0x0000000F
+func (e MessageElement_51) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_51) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_BO_NA_1
+func (e *MessageElement_51) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_BO_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_51) Size() int {
+	return IOASize + 4
+}
+
+// TypeID 类型标识
+func (e MessageElement_51) TypeID() byte {
+	return C_BO_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_51) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_51) AppendElement(dst []byte) []byte {
+	return e.BSI.AppendTo(dst)
+}
+
+// Objects 信息对象
+func (e MessageElement_51) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_BO_NA_1(msgBody []byte) MessageElement_51 {
+	return MessageElement_51{
+		Address: parseIOA(msgBody),
+		BSI:     ParseBSI(msgBody[IOASize:]),
+	}
+}
+
+// NewASDUC_BO_NA_1 创建32比特串命令
+func NewASDUC_BO_NA_1(cause Cause, publicAddress uint16, address uint32, bsi BSI) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_BO_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_51{
+			Address: address,
+			BSI:     bsi,
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package elements
+
+import "io"
+
+// MessageElement_64 带时标CP56Time2a的32比特串命令，《DLT 634.5104-2009》 8.1 64:C_BO_TA_1
+type MessageElement_64 struct {
+	Address uint32     // 信息对象地址
+	BSI     BSI        // 32比特串
+	Time    CP56Time2a // 命令时标
+}
+
+func (e MessageElement_64) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_64) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_64) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] BSI=0x0000000F Time=2024-01-02 03:04:05.678
+func (e MessageElement_64) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_64) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_BO_TA_1
+func (e *MessageElement_64) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_BO_TA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_64) Size() int {
+	return IOASize + 4 + CP56TIME2A_LEN
+}
+
+// TypeID 类型标识
+func (e MessageElement_64) TypeID() byte {
+	return C_BO_TA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_64) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_64) AppendElement(dst []byte) []byte {
+	return e.Time.AppendTo(e.BSI.AppendTo(dst))
+}
+
+// CommandTime 命令时标
+func (e MessageElement_64) CommandTime() CP56Time2a {
+	return e.Time
+}
+
+// Objects 信息对象
+func (e MessageElement_64) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_BO_TA_1(msgBody []byte) MessageElement_64 {
+	return MessageElement_64{
+		Address: parseIOA(msgBody),
+		BSI:     ParseBSI(msgBody[IOASize:]),
+		Time:    ParseCP56Time2a(msgBody[IOASize+4:]),
+	}
+}
+
+// NewASDUC_BO_TA_1 创建带时标CP56Time2a的32比特串命令
+func NewASDUC_BO_TA_1(cause Cause, publicAddress uint16, address uint32, bsi BSI, t CP56Time2a) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_BO_TA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_64{
+			Address: address,
+			BSI:     bsi,
+			Time:    t,
+		},
+	}
+}
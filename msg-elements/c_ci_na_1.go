@@ -0,0 +1,118 @@
+package elements
+
+import "io"
+
+// QCC 计数量召唤命令限定词，《DLT 634.5101-2002》 7.2.6.23
+//
+// RQT(bit1-6):
+//
+//	1 请求计数量第1组
+//	2 请求计数量第2组
+//	3 请求计数量第3组
+//	4 请求计数量第4组
+//	5 总的请求计数量
+//
+// FRZ(bit7-8):
+//
+//	0 读（无冻结或复位）
+//	1 计数量冻结不带复位（被冻结的值为累计量）
+//	2 计数量冻结带复位（被冻结的值为增量信息）
+//	3 计数量复位
+const (
+	QCC_RQT_GROUP_1 = 1
+	QCC_RQT_GROUP_2 = 2
+	QCC_RQT_GROUP_3 = 3
+	QCC_RQT_GROUP_4 = 4
+	QCC_RQT_GENERAL = 5
+
+	QCC_FRZ_READ          = 0x00
+	QCC_FRZ_FREEZE        = 0x40
+	QCC_FRZ_FREEZE_RESET  = 0x80
+	QCC_FRZ_COUNTER_RESET = 0xC0
+)
+
+// MessageElement_101 计数量召唤命令，《DLT 634.5101-2002》 7.3.4.2 101:C_CI_NA_1
+type MessageElement_101 struct {
+	Address uint32 // 信息对象地址，计数量召唤命令为0
+	QCC     byte   // 计数量召唤命令限定词
+}
+
+func (e MessageElement_101) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_101) AppendTo(dst []byte) []byte {
+	return append(appendIOA(dst, e.Address), e.QCC)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_101) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[0] QCC=5
+func (e MessageElement_101) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_101) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_CI_NA_1
+func (e *MessageElement_101) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_CI_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_101) Size() int {
+	return IOASize + 1
+}
+
+// TypeID 类型标识
+func (e MessageElement_101) TypeID() byte {
+	return C_CI_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_101) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_101) AppendElement(dst []byte) []byte {
+	return append(dst, e.QCC)
+}
+
+// Objects 信息对象
+func (e MessageElement_101) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_CI_NA_1(msgBody []byte) MessageElement_101 {
+	return MessageElement_101{
+		Address: parseIOA(msgBody),
+		QCC:     msgBody[3],
+	}
+}
+
+// NewASDUC_CI_NA_1 创建计数量召唤命令
+func NewASDUC_CI_NA_1(cause Cause, publicAddress uint16, qcc byte) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_CI_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_101{
+			Address: 0,
+			QCC:     qcc,
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package elements
+
+import "io"
+
+// MessageElement_103 时钟同步命令，《DLT 634.5101-2002》 7.3.4.4 103:C_CS_NA_1
+type MessageElement_103 struct {
+	Address uint32     // 信息对象地址，时钟同步命令为0
+	Time    CP56Time2a // 时钟
+}
+
+func (e MessageElement_103) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_103) AppendTo(dst []byte) []byte {
+	return e.Time.AppendTo(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_103) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[0] Time=2024-01-02 03:04:05.678
+func (e MessageElement_103) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_103) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_CS_NA_1
+func (e *MessageElement_103) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_CS_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_103) Size() int {
+	return IOASize + CP56TIME2A_LEN
+}
+
+// TypeID 类型标识
+func (e MessageElement_103) TypeID() byte {
+	return C_CS_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_103) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_103) AppendElement(dst []byte) []byte {
+	return e.Time.AppendTo(dst)
+}
+
+// Objects 信息对象
+func (e MessageElement_103) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_CS_NA_1(msgBody []byte) MessageElement_103 {
+	return MessageElement_103{
+		Address: parseIOA(msgBody),
+		Time:    ParseCP56Time2a(msgBody[IOASize:]),
+	}
+}
+
+// NewASDUC_CS_NA_1 创建时钟同步命令
+func NewASDUC_CS_NA_1(cause Cause, publicAddress uint16, t CP56Time2a) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_CS_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_103{
+			Address: 0,
+			Time:    t,
+		},
+	}
+}
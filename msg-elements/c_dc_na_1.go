@@ -0,0 +1,131 @@
+package elements
+
+import (
+	"fmt"
+	"io"
+)
+
+// DCS 双命令状态
+const (
+	DCS_OFF = 1 // 开
+	DCS_ON  = 2 // 合
+)
+
+// DCO 双命令，《DLT 634.5101-2002》 7.2.6.16
+type DCO struct {
+	DCS byte // 双命令状态：0、3 = 不允许 | 1 = 开 | 2 = 合
+	QOC QOC  // 命令限定词
+}
+
+// AppendTo 将编码结果追加到dst
+func (c DCO) AppendTo(dst []byte) []byte {
+	return append(dst, c.QOC.byte()|c.DCS&0x03)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (c DCO) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, c)
+}
+
+// ParseDCO 解析DCO
+func ParseDCO(b byte) DCO {
+	return DCO{DCS: b & 0x03, QOC: parseQOC(b)}
+}
+
+// String 如 合 执行 QU[0]
+func (c DCO) String() string {
+	switch c.DCS {
+	case DCS_OFF:
+		return "开 " + c.QOC.String()
+	case DCS_ON:
+		return "合 " + c.QOC.String()
+	default:
+		return fmt.Sprintf("不允许(%d) %v", c.DCS, c.QOC)
+	}
+}
+
+// MessageElement_46 双命令，《DLT 634.5101-2002》 7.3.2.2 46:C_DC_NA_1
+type MessageElement_46 struct {
+	Address uint32 // 信息对象地址
+	DCO     DCO    // 双命令
+}
+
+func (e MessageElement_46) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_46) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_46) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] DCO=合 选择 QU[0]
+func (e MessageElement_46) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_46) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_DC_NA_1
+func (e *MessageElement_46) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_DC_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_46) Size() int {
+	return IOASize + 1
+}
+
+// TypeID 类型标识
+func (e MessageElement_46) TypeID() byte {
+	return C_DC_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_46) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_46) AppendElement(dst []byte) []byte {
+	return e.DCO.AppendTo(dst)
+}
+
+// Objects 信息对象
+func (e MessageElement_46) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_DC_NA_1(msgBody []byte) MessageElement_46 {
+	return MessageElement_46{
+		Address: parseIOA(msgBody),
+		DCO:     ParseDCO(msgBody[IOASize]),
+	}
+}
+
+// NewASDUC_DC_NA_1 创建双命令
+func NewASDUC_DC_NA_1(cause Cause, publicAddress uint16, address uint32, dco DCO) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_DC_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_46{
+			Address: address,
+			DCO:     dco,
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package elements
+
+import "io"
+
+// MessageElement_59 带时标CP56Time2a的双命令，《DLT 634.5104-2009》 8.1 59:C_DC_TA_1
+type MessageElement_59 struct {
+	Address uint32     // 信息对象地址
+	DCO     DCO        // 双命令
+	Time    CP56Time2a // 命令时标
+}
+
+func (e MessageElement_59) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_59) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_59) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] DCO=合 执行 QU[0] Time=2024-01-02 03:04:05.678
+func (e MessageElement_59) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_59) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_DC_TA_1
+func (e *MessageElement_59) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_DC_TA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_59) Size() int {
+	return IOASize + 1 + CP56TIME2A_LEN
+}
+
+// TypeID 类型标识
+func (e MessageElement_59) TypeID() byte {
+	return C_DC_TA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_59) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_59) AppendElement(dst []byte) []byte {
+	return e.Time.AppendTo(e.DCO.AppendTo(dst))
+}
+
+// CommandTime 命令时标
+func (e MessageElement_59) CommandTime() CP56Time2a {
+	return e.Time
+}
+
+// Objects 信息对象
+func (e MessageElement_59) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_DC_TA_1(msgBody []byte) MessageElement_59 {
+	return MessageElement_59{
+		Address: parseIOA(msgBody),
+		DCO:     ParseDCO(msgBody[IOASize]),
+		Time:    ParseCP56Time2a(msgBody[IOASize+1:]),
+	}
+}
+
+// NewASDUC_DC_TA_1 创建带时标CP56Time2a的双命令
+func NewASDUC_DC_TA_1(cause Cause, publicAddress uint16, address uint32, dco DCO, t CP56Time2a) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_DC_TA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_59{
+			Address: address,
+			DCO:     dco,
+			Time:    t,
+		},
+	}
+}
@@ -1,5 +1,7 @@
 package elements
 
+import "io"
+
 // QOI:
 // 	20 站召唤（全局）
 // 	21 第1组召唤
@@ -24,33 +26,84 @@ const (
 	QIO_GROUP_1     = 21
 )
 
+// MessageElement_100 召唤命令，《DLT 634.5101-2002》 7.3.4.1 100:C_IC_NA_1
 type MessageElement_100 struct {
-	Address byte // 信息对象地址
-	QOI     byte // 召唤限定词，《DLT 634.5101-2002》 7.2.6.22
+	Address uint32 // 信息对象地址，召唤命令为0
+	QOI     byte   // 召唤限定词，《DLT 634.5101-2002》 7.2.6.22
 }
 
 func (e MessageElement_100) ConvertBytes() []byte {
-	return []byte{
-		e.Address,
-		e.QOI,
-	}
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_100) AppendTo(dst []byte) []byte {
+	return append(appendIOA(dst, e.Address), e.QOI)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_100) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[0] QOI=20
+func (e MessageElement_100) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_100) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_IC_NA_1
+func (e *MessageElement_100) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_IC_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_100) Size() int {
+	return IOASize + 1
+}
+
+// TypeID 类型标识
+func (e MessageElement_100) TypeID() byte {
+	return C_IC_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_100) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_100) AppendElement(dst []byte) []byte {
+	return append(dst, e.QOI)
+}
+
+// Objects 信息对象
+func (e MessageElement_100) Objects() []InformationObject {
+	return []InformationObject{e}
 }
 
-func parseC_IC_NA_1(asdu []byte) MessageElement_100 {
+func parseC_IC_NA_1(msgBody []byte) MessageElement_100 {
 	return MessageElement_100{
-		Address: asdu[4],
-		QOI:     asdu[5],
+		Address: parseIOA(msgBody),
+		QOI:     msgBody[3],
 	}
 }
 
-func NewASDUC_IC_NA_1(cause, publicAddress, qoi byte) ASDU {
+// NewASDUC_IC_NA_1 创建召唤命令
+func NewASDUC_IC_NA_1(cause Cause, publicAddress uint16, qoi byte) ASDU {
 	return ASDU{
 		DUI: DUI{
 			TypeIdentification:         C_IC_NA_1,
 			VariableStructureQualifier: 0x01,
-			Cause:                  cause,
-			PublicAddressLow:       publicAddress,
-			PublicAddressHigEnable: false,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
 		},
 		MessageBody: MessageElement_100{
 			Address: 0,
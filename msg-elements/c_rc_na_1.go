@@ -0,0 +1,131 @@
+package elements
+
+import (
+	"fmt"
+	"io"
+)
+
+// RCS 步调节命令状态
+const (
+	RCS_LOWER  = 1 // 降一步
+	RCS_HIGHER = 2 // 升一步
+)
+
+// RCO 步调节命令，《DLT 634.5101-2002》 7.2.6.17
+type RCO struct {
+	RCS byte // 步调节命令状态：0、3 = 不允许 | 1 = 降一步 | 2 = 升一步
+	QOC QOC  // 命令限定词
+}
+
+// AppendTo 将编码结果追加到dst
+func (c RCO) AppendTo(dst []byte) []byte {
+	return append(dst, c.QOC.byte()|c.RCS&0x03)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (c RCO) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, c)
+}
+
+// ParseRCO 解析RCO
+func ParseRCO(b byte) RCO {
+	return RCO{RCS: b & 0x03, QOC: parseQOC(b)}
+}
+
+// String 如 升一步 执行 QU[0]
+func (c RCO) String() string {
+	switch c.RCS {
+	case RCS_LOWER:
+		return "降一步 " + c.QOC.String()
+	case RCS_HIGHER:
+		return "升一步 " + c.QOC.String()
+	default:
+		return fmt.Sprintf("不允许(%d) %v", c.RCS, c.QOC)
+	}
+}
+
+// MessageElement_47 步调节命令，《DLT 634.5101-2002》 7.3.2.3 47:C_RC_NA_1
+type MessageElement_47 struct {
+	Address uint32 // 信息对象地址
+	RCO     RCO    // 步调节命令
+}
+
+func (e MessageElement_47) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_47) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_47) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] RCO=升一步 执行 QU[0]
+func (e MessageElement_47) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_47) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_RC_NA_1
+func (e *MessageElement_47) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_RC_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_47) Size() int {
+	return IOASize + 1
+}
+
+// TypeID 类型标识
+func (e MessageElement_47) TypeID() byte {
+	return C_RC_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_47) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_47) AppendElement(dst []byte) []byte {
+	return e.RCO.AppendTo(dst)
+}
+
+// Objects 信息对象
+func (e MessageElement_47) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_RC_NA_1(msgBody []byte) MessageElement_47 {
+	return MessageElement_47{
+		Address: parseIOA(msgBody),
+		RCO:     ParseRCO(msgBody[IOASize]),
+	}
+}
+
+// NewASDUC_RC_NA_1 创建步调节命令
+func NewASDUC_RC_NA_1(cause Cause, publicAddress uint16, address uint32, rco RCO) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_RC_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_47{
+			Address: address,
+			RCO:     rco,
+		},
+	}
+}
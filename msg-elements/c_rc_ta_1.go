@@ -0,0 +1,97 @@
+package elements
+
+import "io"
+
+// MessageElement_60 带时标CP56Time2a的步调节命令，《DLT 634.5104-2009》 8.1 60:C_RC_TA_1
+type MessageElement_60 struct {
+	Address uint32     // 信息对象地址
+	RCO     RCO        // 步调节命令
+	Time    CP56Time2a // 命令时标
+}
+
+func (e MessageElement_60) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_60) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_60) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] RCO=升一步 执行 QU[0] Time=2024-01-02 03:04:05.678
+func (e MessageElement_60) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_60) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_RC_TA_1
+func (e *MessageElement_60) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_RC_TA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_60) Size() int {
+	return IOASize + 1 + CP56TIME2A_LEN
+}
+
+// TypeID 类型标识
+func (e MessageElement_60) TypeID() byte {
+	return C_RC_TA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_60) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_60) AppendElement(dst []byte) []byte {
+	return e.Time.AppendTo(e.RCO.AppendTo(dst))
+}
+
+// CommandTime 命令时标
+func (e MessageElement_60) CommandTime() CP56Time2a {
+	return e.Time
+}
+
+// Objects 信息对象
+func (e MessageElement_60) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_RC_TA_1(msgBody []byte) MessageElement_60 {
+	return MessageElement_60{
+		Address: parseIOA(msgBody),
+		RCO:     ParseRCO(msgBody[IOASize]),
+		Time:    ParseCP56Time2a(msgBody[IOASize+1:]),
+	}
+}
+
+// NewASDUC_RC_TA_1 创建带时标CP56Time2a的步调节命令
+func NewASDUC_RC_TA_1(cause Cause, publicAddress uint16, address uint32, rco RCO, t CP56Time2a) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_RC_TA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_60{
+			Address: address,
+			RCO:     rco,
+			Time:    t,
+		},
+	}
+}
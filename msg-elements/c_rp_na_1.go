@@ -0,0 +1,98 @@
+package elements
+
+import "io"
+
+// QRP:
+// 	1 进程的总复位
+// 	2 复位事件缓冲区等待处理的带时标的信息
+
+const (
+	QRP_GENERAL = 1
+	QRP_EVENTS  = 2
+)
+
+// MessageElement_105 复位进程命令，《DLT 634.5101-2002》 7.3.4.7 105:C_RP_NA_1
+type MessageElement_105 struct {
+	Address uint32 // 信息对象地址，复位进程命令为0
+	QRP     byte   // 复位进程命令限定词，《DLT 634.5101-2002》 7.2.6.27
+}
+
+func (e MessageElement_105) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_105) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_105) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[0] QRP=1
+func (e MessageElement_105) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_105) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_RP_NA_1
+func (e *MessageElement_105) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_RP_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_105) Size() int {
+	return IOASize + 1
+}
+
+// TypeID 类型标识
+func (e MessageElement_105) TypeID() byte {
+	return C_RP_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_105) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_105) AppendElement(dst []byte) []byte {
+	return append(dst, e.QRP)
+}
+
+// Objects 信息对象
+func (e MessageElement_105) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_RP_NA_1(msgBody []byte) MessageElement_105 {
+	return MessageElement_105{
+		Address: parseIOA(msgBody),
+		QRP:     msgBody[IOASize],
+	}
+}
+
+// NewASDUC_RP_NA_1 创建复位进程命令
+func NewASDUC_RP_NA_1(cause Cause, publicAddress uint16, address uint32, qrp byte) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_RP_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_105{
+			Address: address,
+			QRP:     qrp,
+		},
+	}
+}
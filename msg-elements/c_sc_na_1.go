@@ -0,0 +1,157 @@
+package elements
+
+import (
+	"fmt"
+	"io"
+)
+
+// QOC 命令限定词，《DLT 634.5101-2002》 7.2.6.26
+type QOC struct {
+	QU     byte // 0 = 无另外的定义 | 1 = 短脉冲持续时间 | 2 = 长脉冲持续时间 | 3 = 持续输出
+	Select bool // S/E：false(0) = 执行 | true(1) = 选择
+}
+
+func (q QOC) byte() byte {
+	b := q.QU & 0x1F << 2
+	if q.Select {
+		b |= 0x80
+	}
+	return b
+}
+
+func parseQOC(b byte) QOC {
+	return QOC{QU: b >> 2 & 0x1F, Select: b&0x80 != 0}
+}
+
+// String 如 选择 QU[1]
+func (q QOC) String() string {
+	if q.Select {
+		return fmt.Sprintf("选择 QU[%d]", q.QU)
+	}
+	return fmt.Sprintf("执行 QU[%d]", q.QU)
+}
+
+// SCO 单命令，《DLT 634.5101-2002》 7.2.6.15
+type SCO struct {
+	SCS bool // 单命令状态：false(0) = 开 | true(1) = 合
+	QOC QOC  // 命令限定词
+}
+
+// AppendTo 将编码结果追加到dst
+func (c SCO) AppendTo(dst []byte) []byte {
+	b := c.QOC.byte()
+	if c.SCS {
+		b |= 0x01
+	}
+	return append(dst, b)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (c SCO) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, c)
+}
+
+// ParseSCO 解析SCO
+func ParseSCO(b byte) SCO {
+	return SCO{SCS: b&0x01 != 0, QOC: parseQOC(b)}
+}
+
+// String 如 合 执行 QU[0]
+func (c SCO) String() string {
+	if c.SCS {
+		return "合 " + c.QOC.String()
+	}
+	return "开 " + c.QOC.String()
+}
+
+// TimedCommand 带时标CP56Time2a的命令（C_SC_TA_1至C_BO_TA_1）
+type TimedCommand interface {
+	InformationObject
+	CommandTime() CP56Time2a // 命令时标
+}
+
+// MessageElement_45 单命令，《DLT 634.5101-2002》 7.3.2.1 45:C_SC_NA_1
+type MessageElement_45 struct {
+	Address uint32 // 信息对象地址
+	SCO     SCO    // 单命令
+}
+
+func (e MessageElement_45) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_45) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_45) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] SCO=合 执行 QU[0]
+func (e MessageElement_45) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_45) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_SC_NA_1
+func (e *MessageElement_45) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_SC_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_45) Size() int {
+	return IOASize + 1
+}
+
+// TypeID 类型标识
+func (e MessageElement_45) TypeID() byte {
+	return C_SC_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_45) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_45) AppendElement(dst []byte) []byte {
+	return e.SCO.AppendTo(dst)
+}
+
+// Objects 信息对象
+func (e MessageElement_45) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_SC_NA_1(msgBody []byte) MessageElement_45 {
+	return MessageElement_45{
+		Address: parseIOA(msgBody),
+		SCO:     ParseSCO(msgBody[IOASize]),
+	}
+}
+
+// NewASDUC_SC_NA_1 创建单命令
+func NewASDUC_SC_NA_1(cause Cause, publicAddress uint16, address uint32, sco SCO) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_SC_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_45{
+			Address: address,
+			SCO:     sco,
+		},
+	}
+}
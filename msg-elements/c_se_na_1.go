@@ -0,0 +1,130 @@
+package elements
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// QOS 设定命令限定词，《DLT 634.5101-2002》 7.2.6.39
+type QOS struct {
+	QL     byte // 0 = 缺省 | 1-63 = 标准定义保留 | 64-127 = 专用
+	Select bool // S/E：false(0) = 执行 | true(1) = 选择
+}
+
+// AppendTo 将编码结果追加到dst
+func (q QOS) AppendTo(dst []byte) []byte {
+	b := q.QL & 0x7F
+	if q.Select {
+		b |= 0x80
+	}
+	return append(dst, b)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (q QOS) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, q)
+}
+
+// ParseQOS 解析QOS
+func ParseQOS(b byte) QOS {
+	return QOS{QL: b & 0x7F, Select: b&0x80 != 0}
+}
+
+// String 如 选择 QL[0]
+func (q QOS) String() string {
+	if q.Select {
+		return fmt.Sprintf("选择 QL[%d]", q.QL)
+	}
+	return fmt.Sprintf("执行 QL[%d]", q.QL)
+}
+
+// MessageElement_48 设定值命令，规一化值，《DLT 634.5101-2002》 7.3.2.4 48:C_SE_NA_1
+type MessageElement_48 struct {
+	Address uint32 // 信息对象地址
+	Value   NVA    // 规一化值
+	QOS     QOS    // 设定命令限定词
+}
+
+func (e MessageElement_48) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_48) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_48) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] Value=0.5 QOS=执行 QL[0]
+func (e MessageElement_48) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_48) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_SE_NA_1
+func (e *MessageElement_48) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_SE_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_48) Size() int {
+	return IOASize + 3
+}
+
+// TypeID 类型标识
+func (e MessageElement_48) TypeID() byte {
+	return C_SE_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_48) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_48) AppendElement(dst []byte) []byte {
+	dst = append(dst, byte(e.Value), byte(e.Value>>8))
+	return e.QOS.AppendTo(dst)
+}
+
+// Objects 信息对象
+func (e MessageElement_48) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_SE_NA_1(msgBody []byte) MessageElement_48 {
+	return MessageElement_48{
+		Address: parseIOA(msgBody),
+		Value:   NVA(binary.LittleEndian.Uint16(msgBody[IOASize:])),
+		QOS:     ParseQOS(msgBody[IOASize+2]),
+	}
+}
+
+// NewASDUC_SE_NA_1 创建设定值命令，规一化值
+func NewASDUC_SE_NA_1(cause Cause, publicAddress uint16, address uint32, value NVA, qos QOS) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_SE_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_48{
+			Address: address,
+			Value:   value,
+			QOS:     qos,
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package elements
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// MessageElement_49 设定值命令，标度化值，《DLT 634.5101-2002》 7.3.2.5 49:C_SE_NB_1
+type MessageElement_49 struct {
+	Address uint32 // 信息对象地址
+	Value   int16  // 标度化值
+	QOS     QOS    // 设定命令限定词
+}
+
+func (e MessageElement_49) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_49) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_49) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] Value=100 QOS=执行 QL[0]
+func (e MessageElement_49) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_49) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_SE_NB_1
+func (e *MessageElement_49) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_SE_NB_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_49) Size() int {
+	return IOASize + 3
+}
+
+// TypeID 类型标识
+func (e MessageElement_49) TypeID() byte {
+	return C_SE_NB_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_49) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_49) AppendElement(dst []byte) []byte {
+	dst = append(dst, byte(e.Value), byte(e.Value>>8))
+	return e.QOS.AppendTo(dst)
+}
+
+// Objects 信息对象
+func (e MessageElement_49) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_SE_NB_1(msgBody []byte) MessageElement_49 {
+	return MessageElement_49{
+		Address: parseIOA(msgBody),
+		Value:   int16(binary.LittleEndian.Uint16(msgBody[IOASize:])),
+		QOS:     ParseQOS(msgBody[IOASize+2]),
+	}
+}
+
+// NewASDUC_SE_NB_1 创建设定值命令，标度化值
+func NewASDUC_SE_NB_1(cause Cause, publicAddress uint16, address uint32, value int16, qos QOS) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_SE_NB_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_49{
+			Address: address,
+			Value:   value,
+			QOS:     qos,
+		},
+	}
+}
@@ -0,0 +1,98 @@
+package elements
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// MessageElement_50 设定值命令，短浮点数，《DLT 634.5101-2002》 7.3.2.6 50:C_SE_NC_1
+type MessageElement_50 struct {
+	Address uint32  // 信息对象地址
+	Value   float32 // 短浮点数
+	QOS     QOS     // 设定命令限定词
+}
+
+func (e MessageElement_50) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_50) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_50) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] Value=1.5 QOS=执行 QL[0]
+func (e MessageElement_50) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_50) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_SE_NC_1
+func (e *MessageElement_50) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_SE_NC_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_50) Size() int {
+	return IOASize + 5
+}
+
+// TypeID 类型标识
+func (e MessageElement_50) TypeID() byte {
+	return C_SE_NC_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_50) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_50) AppendElement(dst []byte) []byte {
+	v := math.Float32bits(e.Value)
+	dst = append(dst, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+	return e.QOS.AppendTo(dst)
+}
+
+// Objects 信息对象
+func (e MessageElement_50) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_SE_NC_1(msgBody []byte) MessageElement_50 {
+	return MessageElement_50{
+		Address: parseIOA(msgBody),
+		Value:   math.Float32frombits(binary.LittleEndian.Uint32(msgBody[IOASize:])),
+		QOS:     ParseQOS(msgBody[IOASize+4]),
+	}
+}
+
+// NewASDUC_SE_NC_1 创建设定值命令，短浮点数
+func NewASDUC_SE_NC_1(cause Cause, publicAddress uint16, address uint32, value float32, qos QOS) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_SE_NC_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_50{
+			Address: address,
+			Value:   value,
+			QOS:     qos,
+		},
+	}
+}
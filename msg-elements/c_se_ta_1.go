@@ -0,0 +1,104 @@
+package elements
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// MessageElement_61 带时标CP56Time2a的设定值命令，规一化值，《DLT 634.5104-2009》 8.1 61:C_SE_TA_1
+type MessageElement_61 struct {
+	Address uint32     // 信息对象地址
+	Value   NVA        // 规一化值
+	QOS     QOS        // 设定命令限定词
+	Time    CP56Time2a // 命令时标
+}
+
+func (e MessageElement_61) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_61) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_61) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] Value=0.5 QOS=执行 QL[0] Time=2024-01-02 03:04:05.678
+func (e MessageElement_61) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_61) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_SE_TA_1
+func (e *MessageElement_61) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_SE_TA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_61) Size() int {
+	return IOASize + 3 + CP56TIME2A_LEN
+}
+
+// TypeID 类型标识
+func (e MessageElement_61) TypeID() byte {
+	return C_SE_TA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_61) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_61) AppendElement(dst []byte) []byte {
+	dst = append(dst, byte(e.Value), byte(e.Value>>8))
+	return e.Time.AppendTo(e.QOS.AppendTo(dst))
+}
+
+// CommandTime 命令时标
+func (e MessageElement_61) CommandTime() CP56Time2a {
+	return e.Time
+}
+
+// Objects 信息对象
+func (e MessageElement_61) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_SE_TA_1(msgBody []byte) MessageElement_61 {
+	return MessageElement_61{
+		Address: parseIOA(msgBody),
+		Value:   NVA(binary.LittleEndian.Uint16(msgBody[IOASize:])),
+		QOS:     ParseQOS(msgBody[IOASize+2]),
+		Time:    ParseCP56Time2a(msgBody[IOASize+3:]),
+	}
+}
+
+// NewASDUC_SE_TA_1 创建带时标CP56Time2a的设定值命令，规一化值
+func NewASDUC_SE_TA_1(cause Cause, publicAddress uint16, address uint32, value NVA, qos QOS, t CP56Time2a) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_SE_TA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_61{
+			Address: address,
+			Value:   value,
+			QOS:     qos,
+			Time:    t,
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package elements
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// MessageElement_63 带时标CP56Time2a的设定值命令，短浮点数，《DLT 634.5104-2009》 8.1 63:C_SE_TC_1
+type MessageElement_63 struct {
+	Address uint32     // 信息对象地址
+	Value   float32    // 短浮点数
+	QOS     QOS        // 设定命令限定词
+	Time    CP56Time2a // 命令时标
+}
+
+func (e MessageElement_63) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_63) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_63) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] Value=1.5 QOS=执行 QL[0] Time=2024-01-02 03:04:05.678
+func (e MessageElement_63) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_63) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_SE_TC_1
+func (e *MessageElement_63) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_SE_TC_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_63) Size() int {
+	return IOASize + 5 + CP56TIME2A_LEN
+}
+
+// TypeID 类型标识
+func (e MessageElement_63) TypeID() byte {
+	return C_SE_TC_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_63) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_63) AppendElement(dst []byte) []byte {
+	v := math.Float32bits(e.Value)
+	dst = append(dst, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+	return e.Time.AppendTo(e.QOS.AppendTo(dst))
+}
+
+// CommandTime 命令时标
+func (e MessageElement_63) CommandTime() CP56Time2a {
+	return e.Time
+}
+
+// Objects 信息对象
+func (e MessageElement_63) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_SE_TC_1(msgBody []byte) MessageElement_63 {
+	return MessageElement_63{
+		Address: parseIOA(msgBody),
+		Value:   math.Float32frombits(binary.LittleEndian.Uint32(msgBody[IOASize:])),
+		QOS:     ParseQOS(msgBody[IOASize+4]),
+		Time:    ParseCP56Time2a(msgBody[IOASize+5:]),
+	}
+}
+
+// NewASDUC_SE_TC_1 创建带时标CP56Time2a的设定值命令，短浮点数
+func NewASDUC_SE_TC_1(cause Cause, publicAddress uint16, address uint32, value float32, qos QOS, t CP56Time2a) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_SE_TC_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_63{
+			Address: address,
+			Value:   value,
+			QOS:     qos,
+			Time:    t,
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package elements
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// FBP_TEST 测试命令的固定测试图像，《DLT 634.5101-2002》 7.2.6.14
+const FBP_TEST = 0x55AA
+
+// MessageElement_104 测试命令，《DLT 634.5101-2002》 7.3.4.5 104:C_TS_NA_1
+type MessageElement_104 struct {
+	Address uint32 // 信息对象地址，测试命令为0
+	FBP     uint16 // 固定测试图像，应为 FBP_TEST
+}
+
+func (e MessageElement_104) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_104) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_104) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[0] FBP=43605
+func (e MessageElement_104) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_104) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_TS_NA_1
+func (e *MessageElement_104) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_TS_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_104) Size() int {
+	return IOASize + 2
+}
+
+// TypeID 类型标识
+func (e MessageElement_104) TypeID() byte {
+	return C_TS_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_104) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_104) AppendElement(dst []byte) []byte {
+	return append(dst, byte(e.FBP), byte(e.FBP>>8))
+}
+
+// Objects 信息对象
+func (e MessageElement_104) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_TS_NA_1(msgBody []byte) MessageElement_104 {
+	return MessageElement_104{
+		Address: parseIOA(msgBody),
+		FBP:     binary.LittleEndian.Uint16(msgBody[IOASize:]),
+	}
+}
+
+// NewASDUC_TS_NA_1 创建测试命令
+func NewASDUC_TS_NA_1(cause Cause, publicAddress uint16, address uint32, fbp uint16) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_TS_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_104{
+			Address: address,
+			FBP:     fbp,
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package elements
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// MessageElement_107 带时标CP56Time2a的测试命令，《DLT 634.5104-2009》 8.2 107:C_TS_TA_1
+type MessageElement_107 struct {
+	Address uint32     // 信息对象地址，测试命令为0
+	TSC     uint16     // 测试顺序计数器
+	Time    CP56Time2a // 时标
+}
+
+func (e MessageElement_107) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_107) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_107) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[0] TSC=1 Time=2024-01-02 03:04:05.678
+func (e MessageElement_107) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_107) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为C_TS_TA_1
+func (e *MessageElement_107) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, C_TS_TA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_107) Size() int {
+	return IOASize + 2 + CP56TIME2A_LEN
+}
+
+// TypeID 类型标识
+func (e MessageElement_107) TypeID() byte {
+	return C_TS_TA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_107) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_107) AppendElement(dst []byte) []byte {
+	dst = append(dst, byte(e.TSC), byte(e.TSC>>8))
+	return e.Time.AppendTo(dst)
+}
+
+// Objects 信息对象
+func (e MessageElement_107) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseC_TS_TA_1(msgBody []byte) MessageElement_107 {
+	return MessageElement_107{
+		Address: parseIOA(msgBody),
+		TSC:     binary.LittleEndian.Uint16(msgBody[IOASize:]),
+		Time:    ParseCP56Time2a(msgBody[IOASize+2:]),
+	}
+}
+
+// NewASDUC_TS_TA_1 创建带时标CP56Time2a的测试命令
+func NewASDUC_TS_TA_1(cause Cause, publicAddress uint16, address uint32, tsc uint16, t CP56Time2a) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         C_TS_TA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_107{
+			Address: address,
+			TSC:     tsc,
+			Time:    t,
+		},
+	}
+}
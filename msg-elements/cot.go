@@ -1,14 +1,133 @@
 package elements
 
-// cot cause of translation 传送原因，《DLT 634.5101-2002》 7.2.3.1
+import (
+	"fmt"
+	"strings"
+)
+
+// Cause 传送原因值（不含T位及P/N位），《DLT 634.5101-2002》 7.2.3.1
+type Cause byte
+
+const (
+	COT_PERCYC       Cause = 1  // 周期、循环
+	COT_BACK         Cause = 2  // 背景扫描
+	COT_SPONT        Cause = 3  // 突发（自发）
+	COT_INIT         Cause = 4  // 初始化
+	COT_REQ          Cause = 5  // 请求或者被请求
+	COT_ACT          Cause = 6  // 激活
+	COT_ACTCON       Cause = 7  // 激活确认
+	COT_DEACT        Cause = 8  // 停止激活
+	COT_DEACTCON     Cause = 9  // 停止激活确认
+	COT_ACTTERM      Cause = 10 // 激活终止
+	COT_RETREM       Cause = 11 // 远方命令引起的返送信息
+	COT_RETLOC       Cause = 12 // 当地命令引起的返送信息
+	COT_FILE         Cause = 13 // 文件传输
+	COT_INTRGEN      Cause = 20 // 响应站召唤
+	COT_INTRO1       Cause = 21 // 响应第1组召唤，第n组为 COT_INTRO1+n-1
+	COT_INTRO16      Cause = 36 // 响应第16组召唤
+	COT_REQCOGEN     Cause = 37 // 响应计数量站召唤
+	COT_REQCO1       Cause = 38 // 响应第1组计数量召唤，第n组为 COT_REQCO1+n-1
+	COT_REQCO4       Cause = 41 // 响应第4组计数量召唤
+	COT_UNKNOWN_TYPE Cause = 44 // 未知的类型标识
+	COT_UNKNOWN_COT  Cause = 45 // 未知的传送原因
+	COT_UNKNOWN_CA   Cause = 46 // 未知的应用服务数据单元公共地址
+	COT_UNKNOWN_IOA  Cause = 47 // 未知的信息对象地址
+
+	// Deprecated: 传送原因3为突发，使用 COT_SPONT
+	COT_ACTIVE = COT_SPONT
+)
+
+// 传送原因第一个字节各位
 const (
-	COT_ACTIVE   = 3
-	COT_INIT     = 4  // 初始化
-	COT_REQ      = 5  // 请求或者被请求
-	COT_ACT      = 6  // 激活
-	COT_ACTCON   = 7  // 激活确认
-	COT_DEACT    = 8  // 停止激活
-	COT_DEACTCON = 9  // 停止激活确认
-	COT_ACTTERM  = 10 // 激活终止
-	COT_INTRGEN  = 20 // 相应站召唤
+	COT_MASK     = 0x3F // 传送原因值掩码
+	COT_NEGATIVE = 0x40 // P/N位，否定确认
+	COT_TEST     = 0x80 // T位，试验
 )
+
+var causeNames = map[Cause]string{
+	COT_PERCYC:       "per/cyc",
+	COT_BACK:         "back",
+	COT_SPONT:        "spont",
+	COT_INIT:         "init",
+	COT_REQ:          "req",
+	COT_ACT:          "act",
+	COT_ACTCON:       "actcon",
+	COT_DEACT:        "deact",
+	COT_DEACTCON:     "deactcon",
+	COT_ACTTERM:      "actterm",
+	COT_RETREM:       "retrem",
+	COT_RETLOC:       "retloc",
+	COT_FILE:         "file",
+	COT_INTRGEN:      "inrogen",
+	COT_REQCOGEN:     "reqcogen",
+	COT_UNKNOWN_TYPE: "unknown type",
+	COT_UNKNOWN_COT:  "unknown cause",
+	COT_UNKNOWN_CA:   "unknown common address",
+	COT_UNKNOWN_IOA:  "unknown object address",
+}
+
+// String 传送原因助记符，如 actcon(7)
+func (c Cause) String() string {
+	switch {
+	case c >= COT_INTRO1 && c <= COT_INTRO16:
+		return fmt.Sprintf("inro%d(%d)", c-COT_INTRO1+1, byte(c))
+	case c >= COT_REQCO1 && c <= COT_REQCO4:
+		return fmt.Sprintf("reqco%d(%d)", c-COT_REQCO1+1, byte(c))
+	}
+	if name, ok := causeNames[c]; ok {
+		return fmt.Sprintf("%s(%d)", name, byte(c))
+	}
+	return fmt.Sprintf("未定义(%d)", byte(c))
+}
+
+// Valid 传送原因是否为标准定义的值
+func (c Cause) Valid() bool {
+	_, ok := causeNames[c]
+	return ok || c >= COT_INTRO1 && c <= COT_INTRO16 || c >= COT_REQCO1 && c <= COT_REQCO4
+}
+
+// COT 传送原因域，《DLT 634.5101-2002》 7.2.3
+type COT struct {
+	Cause      Cause // 传送原因 1-47
+	Negative   bool  // P/N位，false(0) = 肯定确认 | true(1) = 否定确认
+	Test       bool  // T位，false(0) = 未试验 | true(1) = 试验
+	Originator byte  // 源发站地址，传送原因为2字节时有效
+}
+
+// ParseCOT 由传送原因第一个字节及源发站地址解析传送原因域
+func ParseCOT(b byte, originator byte) COT {
+	return COT{
+		Cause:      Cause(b & COT_MASK),
+		Negative:   b&COT_NEGATIVE != 0,
+		Test:       b&COT_TEST != 0,
+		Originator: originator,
+	}
+}
+
+// Byte 传送原因第一个字节（传送原因、P/N位及T位）
+func (c COT) Byte() byte {
+	b := byte(c.Cause) & COT_MASK
+	if c.Negative {
+		b |= COT_NEGATIVE
+	}
+	if c.Test {
+		b |= COT_TEST
+	}
+	return b
+}
+
+// String 如 actcon(7) 否定 试验 源发站地址[1]
+func (c COT) String() string {
+	var b strings.Builder
+	b.WriteString(c.Cause.String())
+	if c.Negative {
+		b.WriteString(" 否定")
+	}
+	if c.Test {
+		b.WriteString(" 试验")
+	}
+	if c.Originator != 0 {
+		fmt.Fprintf(&b, " 源发站地址[%d]", c.Originator)
+	}
+	return b.String()
+}
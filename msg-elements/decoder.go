@@ -0,0 +1,157 @@
+package elements
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Decoder 零拷贝ASDU解码器
+//
+// 与 ParseASDU 不同，Decoder 不构造信息体结构，而是直接在输入报文上逐个遍历信息对象，
+// SQ=1时自动计算各信息对象的地址。Decoder 可重复使用，每帧报文调用一次 Reset：
+//
+//	d := elements.NewDecoder(elements.DefaultParams)
+//	if err := d.Reset(asdu); err != nil {
+//		return err
+//	}
+//	for d.Next() {
+//		obj := d.Object()
+//		fmt.Println(obj.Address, obj.Value(), obj.Quality())
+//	}
+//
+// Object 返回的 RawObject 引用输入报文，仅在输入报文未被修改前有效。
+type Decoder struct {
+	params Params
+
+	dui    DUI
+	body   []byte
+	size   int  // 信息元素长度（不含信息对象地址）
+	sq     bool // 是否为SQ=1
+	number int  // 信息对象数目
+	index  int  // 下一个信息对象序号
+	base   uint32
+	obj    RawObject
+}
+
+// NewDecoder 创建按参数p解码的解码器
+func NewDecoder(p Params) *Decoder {
+	return &Decoder{params: p}
+}
+
+// Reset 解析asdu的数据单元标识符并校验信息体长度，之后可调用 Next 遍历信息对象
+//
+// 异常与 ParseASDUWithParams 相同。
+func (d *Decoder) Reset(asdu []byte) error {
+	dui, body, err := parseHeader(asdu, d.params)
+	if err != nil {
+		*d = Decoder{params: d.params}
+		return err
+	}
+	d.dui = dui
+	d.body = body
+	d.size, _ = elementSize(dui.TypeIdentification)
+	if dui.TypeIdentification == F_SG_NA_1 {
+		d.size = len(body) - IOASize
+	}
+	d.sq = dui.VSQ().SQ()
+	d.number = dui.VSQ().Number()
+	d.index = 0
+	d.obj = RawObject{}
+	if d.sq {
+		d.base = parseIOA(body)
+		d.body = body[IOASize:]
+	}
+	return nil
+}
+
+// DUI 当前报文的数据单元标识符
+func (d *Decoder) DUI() DUI {
+	return d.dui
+}
+
+// Len 当前报文的信息对象数目
+func (d *Decoder) Len() int {
+	return d.number
+}
+
+// Next 移动到下一个信息对象，没有更多信息对象时返回false
+func (d *Decoder) Next() bool {
+	if d.index >= d.number {
+		return false
+	}
+	var address uint32
+	var raw []byte
+	if d.sq {
+		address = d.base + uint32(d.index)
+		raw = d.body[d.index*d.size : (d.index+1)*d.size]
+	} else {
+		off := d.index * (IOASize + d.size)
+		address = parseIOA(d.body[off:])
+		raw = d.body[off+IOASize : off+IOASize+d.size]
+	}
+	d.obj = RawObject{TypeID: d.dui.TypeIdentification, Address: address, Raw: raw}
+	d.index++
+	return true
+}
+
+// Object 当前信息对象，须在 Next 返回true后调用
+func (d *Decoder) Object() RawObject {
+	return d.obj
+}
+
+// RawObject 未解码的信息对象
+type RawObject struct {
+	TypeID  byte   // 类型标识
+	Address uint32 // 信息对象地址，SQ=1时已按序号展开
+	Raw     []byte // 信息元素原始字节（不含信息对象地址），引用输入报文
+}
+
+// Value 信息对象的值
+//
+// 规一化值为对应的小数，标度化值为原始值，M_ME_NC_1为短浮点数，M_IT_NA_1为计数器读数，
+// 单命令、双命令及步调节命令为命令状态，设定值命令为设定值，
+// 32比特串为无符号整数，成组单点信息为16个遥信状态，召唤命令及参数激活、复位进程命令为限定词，
+// 初始化结束为初始化原因，测量值参数为参数值，测试命令为测试图像或测试顺序计数器，其他类型返回0。
+func (o RawObject) Value() float64 {
+	switch o.TypeID {
+	case M_ME_NA_1, M_ME_ND_1, P_ME_NA_1, C_SE_NA_1, C_SE_TA_1:
+		return NVA(binary.LittleEndian.Uint16(o.Raw)).Float()
+	case M_ME_NB_1, M_ME_TB_1, M_ME_TE_1, P_ME_NB_1, C_SE_NB_1, C_SE_TB_1:
+		return float64(int16(binary.LittleEndian.Uint16(o.Raw)))
+	case M_ME_NC_1, P_ME_NC_1, C_SE_NC_1, C_SE_TC_1:
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(o.Raw)))
+	case M_IT_NA_1:
+		return float64(int32(binary.LittleEndian.Uint32(o.Raw)))
+	case M_BO_NA_1, M_BO_TB_1, C_BO_NA_1, C_BO_TA_1:
+		return float64(binary.LittleEndian.Uint32(o.Raw))
+	case M_PS_NA_1, C_TS_NA_1, C_TS_TA_1:
+		return float64(binary.LittleEndian.Uint16(o.Raw))
+	case C_SC_NA_1, C_SC_TA_1:
+		return float64(o.Raw[0] & 0x01)
+	case C_DC_NA_1, C_DC_TA_1, C_RC_NA_1, C_RC_TA_1:
+		return float64(o.Raw[0] & 0x03)
+	case C_IC_NA_1, C_CI_NA_1, M_EI_NA_1, P_AC_NA_1, C_RP_NA_1:
+		return float64(o.Raw[0])
+	default:
+		return 0
+	}
+}
+
+// Quality 信息对象的品质描述词，累计量仅IV位有效，继电保护设备事件取SEP或QDP中的对应位，
+// 无品质描述词的类型返回零值
+func (o RawObject) Quality() QDS {
+	switch o.TypeID {
+	case M_ME_NA_1, M_ME_NB_1, M_ME_TB_1, M_ME_TE_1:
+		return ParseQDS(o.Raw[2])
+	case M_ME_NC_1, M_BO_NA_1, M_BO_TB_1, M_PS_NA_1:
+		return ParseQDS(o.Raw[4])
+	case M_IT_NA_1:
+		return QDS{IV: o.Raw[4]&0x80 != 0}
+	case M_EP_TD_1:
+		return ParseQDS(o.Raw[0] & 0xF0)
+	case M_EP_TE_1, M_EP_TF_1:
+		return ParseQDS(o.Raw[1] & 0xF0)
+	default:
+		return QDS{}
+	}
+}
@@ -0,0 +1,178 @@
+package elements
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/wangxianzhuo/iec104/internal/frametest"
+)
+
+// flatten 将ParseASDU的解析结果展开为信息对象地址、值、品质描述词
+func flatten(asdu ASDU) (addresses []uint32, values []float64, qds []QDS) {
+	add := func(address uint32, value float64, q QDS) {
+		addresses = append(addresses, address)
+		values = append(values, value)
+		qds = append(qds, q)
+	}
+	for _, o := range asdu.Objects() {
+		switch e := o.(type) {
+		case Measurand:
+			add(o.IOA(), e.Float(), e.Quality())
+		case MessageElement_100:
+			add(o.IOA(), float64(e.QOI), QDS{})
+		case MessageElement_101:
+			add(o.IOA(), float64(e.QCC), QDS{})
+		case MessageElement_70:
+			add(o.IOA(), float64(e.COI.AppendTo(nil)[0]), QDS{})
+		case Parameter:
+			add(o.IOA(), e.Float(), QDS{})
+		case MessageElement_113:
+			add(o.IOA(), float64(e.QPA), QDS{})
+		case MessageElement_104:
+			add(o.IOA(), float64(e.FBP), QDS{})
+		case MessageElement_105:
+			add(o.IOA(), float64(e.QRP), QDS{})
+		case MessageElement_107:
+			add(o.IOA(), float64(e.TSC), QDS{})
+		case MessageElement_45:
+			add(o.IOA(), float64(e.SCO.AppendTo(nil)[0]&0x01), QDS{})
+		case MessageElement_58:
+			add(o.IOA(), float64(e.SCO.AppendTo(nil)[0]&0x01), QDS{})
+		case MessageElement_46:
+			add(o.IOA(), float64(e.DCO.DCS), QDS{})
+		case MessageElement_59:
+			add(o.IOA(), float64(e.DCO.DCS), QDS{})
+		case MessageElement_47:
+			add(o.IOA(), float64(e.RCO.RCS), QDS{})
+		case MessageElement_60:
+			add(o.IOA(), float64(e.RCO.RCS), QDS{})
+		case MessageElement_48:
+			add(o.IOA(), e.Value.Float(), QDS{})
+		case MessageElement_61:
+			add(o.IOA(), e.Value.Float(), QDS{})
+		case MessageElement_49:
+			add(o.IOA(), float64(e.Value), QDS{})
+		case MessageElement_62:
+			add(o.IOA(), float64(e.Value), QDS{})
+		case MessageElement_50:
+			add(o.IOA(), float64(e.Value), QDS{})
+		case MessageElement_63:
+			add(o.IOA(), float64(e.Value), QDS{})
+		case MessageElement_64:
+			add(o.IOA(), float64(e.BSI), QDS{})
+		case MessageElement_120, MessageElement_121, MessageElement_122, MessageElement_123,
+			MessageElement_124, MessageElement_125, MessageElement_126_SQ_0_Ele:
+			add(o.IOA(), 0, QDS{})
+		case MessageElement_103:
+			add(o.IOA(), 0, QDS{})
+		case MessageElement_51:
+			add(o.IOA(), float64(e.BSI), QDS{})
+		case MessageElement_20_SQ_0_Ele:
+			add(o.IOA(), float64(e.Core.SCD.ST), e.Core.QDS)
+		case MessageElement_38_SQ_0_Ele:
+			add(o.IOA(), 0, QDS{BL: e.Core.SEP.BL, SB: e.Core.SEP.SB, NT: e.Core.SEP.NT, IV: e.Core.SEP.IV})
+		case MessageElement_39_SQ_0_Ele:
+			add(o.IOA(), 0, QDS{BL: e.Core.QDP.BL, SB: e.Core.QDP.SB, NT: e.Core.QDP.NT, IV: e.Core.QDP.IV})
+		case MessageElement_40_SQ_0_Ele:
+			add(o.IOA(), 0, QDS{BL: e.Core.QDP.BL, SB: e.Core.QDP.SB, NT: e.Core.QDP.NT, IV: e.Core.QDP.IV})
+		}
+	}
+	return
+}
+
+func Test_Decoder(t *testing.T) {
+	inputs := frametest.Load(t, "testdata/asdu.txt")
+	r := rand.New(rand.NewSource(1))
+	for _, typ := range testTypes {
+		for i := 0; i < 20; i++ {
+			inputs = append(inputs, randomASDU(r, typ, !singleObject(typ) && i%2 == 1).ConvertBytes())
+		}
+	}
+
+	d := NewDecoder(DefaultParams)
+	for _, input := range inputs {
+		asdu, err := ParseASDU(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := d.Reset(input); err != nil {
+			t.Fatal(err)
+		}
+		if d.DUI() != asdu.DUI {
+			t.Fatalf("报文[%X]数据单元标识符[%+v]应为[%+v]", input, d.DUI(), asdu.DUI)
+		}
+		addresses, values, qds := flatten(asdu)
+		if d.Len() != len(addresses) {
+			t.Fatalf("报文[%X]信息对象数目[%d]应为[%d]", input, d.Len(), len(addresses))
+		}
+		i := 0
+		for d.Next() {
+			obj := d.Object()
+			value := obj.Value()
+			sameValue := value == values[i] || math.IsNaN(value) && math.IsNaN(values[i])
+			if obj.Address != addresses[i] || !sameValue || obj.Quality() != qds[i] {
+				t.Fatalf("报文[%X]第%d个信息对象[%d %v %+v]应为[%d %v %+v]", input, i,
+					obj.Address, value, obj.Quality(), addresses[i], values[i], qds[i])
+			}
+			i++
+		}
+		if i != len(addresses) {
+			t.Fatalf("报文[%X]只遍历了%d个信息对象", input, i)
+		}
+	}
+
+	if err := d.Reset([]byte{M_ME_NC_1, 0x02, 0x03, 0x00, 0x01, 0x00}); err == nil {
+		t.Fatal("信息体不足时Reset应返回异常")
+	}
+	if d.Next() {
+		t.Fatal("Reset失败后Next应返回false")
+	}
+}
+
+// benchmarkASDU 30个短浮点数测量值，SQ=0
+func benchmarkASDU() []byte {
+	body := make(MessageElement_13_SQ_0, 0, 30)
+	for i := 0; i < 30; i++ {
+		body = append(body, MessageElement_13_SQ_0_Ele{
+			Address: uint32(0x4001 + i),
+			Core:    MessageElementCore_13{Value: float32(i) * 1.5},
+		})
+	}
+	return ASDU{
+		DUI:         DefaultParams.Apply(DUI{TypeIdentification: M_ME_NC_1, VariableStructureQualifier: 30, COT: COT{Cause: COT_INTRGEN}, PublicAddressLow: 1}),
+		MessageBody: body,
+	}.ConvertBytes()
+}
+
+func Benchmark_ParseASDU(b *testing.B) {
+	input := benchmarkASDU()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		asdu, err := ParseASDU(input)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var sum float32
+		for _, e := range asdu.MessageBody.(MessageElement_13_SQ_0) {
+			sum += e.Core.Value
+		}
+		_ = sum
+	}
+}
+
+func Benchmark_Decoder(b *testing.B) {
+	input := benchmarkASDU()
+	d := NewDecoder(DefaultParams)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := d.Reset(input); err != nil {
+			b.Fatal(err)
+		}
+		var sum float64
+		for d.Next() {
+			sum += d.Object().Value()
+		}
+		_ = sum
+	}
+}
@@ -0,0 +1,46 @@
+package elements
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrTruncated 报文长度不足，如信息对象被截断
+	ErrTruncated = errors.New("报文长度不足")
+	// ErrLengthMismatch 报文长度与长度域或信息对象数目不匹配
+	ErrLengthMismatch = errors.New("报文长度不匹配")
+	// ErrUnknownTypeID 未知类型标识，可用 errors.As 获取 *UnknownTypeError
+	ErrUnknownTypeID = errors.New("未知类型标识")
+)
+
+// ParseError 报文解析异常，记录异常位置及原始报文
+//
+// 使用 errors.Is 判断具体异常类型，如 errors.Is(err, ErrTruncated)。
+type ParseError struct {
+	Offset int    // 异常字节在原始报文中的偏移
+	Data   []byte // 原始报文
+	Err    error  // 具体异常
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("报文[%X]偏移[%d]处解析异常: %v", e.Data, e.Offset, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// UnknownTypeError 未知类型标识异常
+type UnknownTypeError struct {
+	TypeID byte // 类型标识
+}
+
+func (e *UnknownTypeError) Error() string {
+	return fmt.Sprintf("%v[%d]", ErrUnknownTypeID, e.TypeID)
+}
+
+// Is 使 errors.Is(err, ErrUnknownTypeID) 成立
+func (e *UnknownTypeError) Is(target error) bool {
+	return target == ErrUnknownTypeID
+}
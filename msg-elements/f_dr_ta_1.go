@@ -0,0 +1,212 @@
+package elements
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+const (
+	F_DR_TA_1_SQ_1_MSG_LEN = 13
+	F_DR_TA_1_SQ_0_MSG_LEN = 16
+)
+
+// MessageElement_126_SQ_1 目录，《DLT 634.5101-2002》 7.3.6.7 126:F_DR_TA_1，SQ=1的信息元素
+type MessageElement_126_SQ_1 struct {
+	Address uint32
+	Cores   []MessageElementCore_126
+}
+
+func (e MessageElement_126_SQ_1) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_126_SQ_1) AppendTo(dst []byte) []byte {
+	dst = appendIOA(dst, e.Address)
+	for _, c := range e.Cores {
+		dst = c.AppendTo(dst)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_126_SQ_1) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_126_SQ_1) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_126_SQ_1) Size() int {
+	return IOASize + len(e.Cores)*F_DR_TA_1_SQ_1_MSG_LEN
+}
+
+// Objects 按序号展开地址后的信息对象
+func (e MessageElement_126_SQ_1) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e.Cores))
+	for i, c := range e.Cores {
+		objs[i] = MessageElement_126_SQ_0_Ele{Address: e.Address + uint32(i), Core: c}
+	}
+	return objs
+}
+
+// MessageElement_126_SQ_0_Ele 目录，《DLT 634.5101-2002》 7.3.6.7 126:F_DR_TA_1，SQ=0的信息元素
+type MessageElement_126_SQ_0_Ele struct {
+	Address uint32
+	Core    MessageElementCore_126
+}
+
+func (e MessageElement_126_SQ_0_Ele) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_126_SQ_0_Ele) AppendTo(dst []byte) []byte {
+	return e.Core.AppendTo(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_126_SQ_0_Ele) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] NOF=1 LOF=1024 SOF=0 LFD Time=2024-01-02 03:04:05.678
+func (e MessageElement_126_SQ_0_Ele) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_126_SQ_0_Ele) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为F_DR_TA_1
+func (e *MessageElement_126_SQ_0_Ele) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, F_DR_TA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_126_SQ_0_Ele) Size() int {
+	return F_DR_TA_1_SQ_0_MSG_LEN
+}
+
+// TypeID 类型标识
+func (e MessageElement_126_SQ_0_Ele) TypeID() byte {
+	return F_DR_TA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_126_SQ_0_Ele) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_126_SQ_0_Ele) AppendElement(dst []byte) []byte {
+	return e.Core.AppendTo(dst)
+}
+
+type MessageElement_126_SQ_0 []MessageElement_126_SQ_0_Ele
+
+func (e MessageElement_126_SQ_0) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_126_SQ_0) AppendTo(dst []byte) []byte {
+	for _, ele := range e {
+		dst = ele.AppendTo(dst)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_126_SQ_0) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_126_SQ_0) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_126_SQ_0) Size() int {
+	return len(e) * F_DR_TA_1_SQ_0_MSG_LEN
+}
+
+// Objects 信息对象
+func (e MessageElement_126_SQ_0) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e))
+	for i, ele := range e {
+		objs[i] = ele
+	}
+	return objs
+}
+
+// MessageElementCore_126 目录中的一个文件或子目录
+type MessageElementCore_126 struct {
+	NOF  uint16     // 文件或子目录名称
+	LOF  uint32     // 文件长度，3字节
+	SOF  SOF        // 文件状态
+	Time CP56Time2a // 文件的创建时间
+}
+
+func (c MessageElementCore_126) ConvertBytes() []byte {
+	return c.AppendTo(make([]byte, 0, F_DR_TA_1_SQ_1_MSG_LEN))
+}
+
+// AppendTo 将编码结果追加到dst
+func (c MessageElementCore_126) AppendTo(dst []byte) []byte {
+	dst = append(dst, byte(c.NOF), byte(c.NOF>>8))
+	dst = appendUint24(dst, c.LOF)
+	return c.Time.AppendTo(c.SOF.AppendTo(dst))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (c MessageElementCore_126) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, c)
+}
+
+// String 如 NOF=1 LOF=1024 SOF=0 LFD Time=2024-01-02 03:04:05.678
+func (c MessageElementCore_126) String() string {
+	return objectString(c)
+}
+
+func parseF_DR_TA_1(msgBody []byte, dui DUI) BytesConverter {
+	vsq := dui.VSQ()
+	number := vsq.Number()
+
+	switch {
+	case !vsq.SQ():
+		elements := make(MessageElement_126_SQ_0, 0, number)
+		for i := 0; i < number*F_DR_TA_1_SQ_0_MSG_LEN; i += F_DR_TA_1_SQ_0_MSG_LEN {
+			elements = append(elements, MessageElement_126_SQ_0_Ele{
+				Address: parseIOA(msgBody[i:]),
+				Core:    parseCore_126(msgBody[i+IOASize:]),
+			})
+		}
+		return elements
+	default:
+		elements := MessageElement_126_SQ_1{
+			Address: parseIOA(msgBody),
+			Cores:   make([]MessageElementCore_126, 0, number),
+		}
+		msgBody = msgBody[IOASize:]
+		for i := 0; i < number*F_DR_TA_1_SQ_1_MSG_LEN; i += F_DR_TA_1_SQ_1_MSG_LEN {
+			elements.Cores = append(elements.Cores, parseCore_126(msgBody[i:]))
+		}
+		return elements
+	}
+}
+
+func parseCore_126(b []byte) MessageElementCore_126 {
+	return MessageElementCore_126{
+		NOF:  binary.LittleEndian.Uint16(b),
+		LOF:  parseUint24(b[2:]),
+		SOF:  ParseSOF(b[5]),
+		Time: ParseCP56Time2a(b[6:]),
+	}
+}
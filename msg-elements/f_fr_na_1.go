@@ -0,0 +1,100 @@
+package elements
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// MessageElement_120 文件准备就绪，《DLT 634.5101-2002》 7.3.6.1 120:F_FR_NA_1
+type MessageElement_120 struct {
+	Address uint32 // 信息对象地址
+	NOF     uint16 // 文件名称
+	LOF     uint32 // 文件长度，3字节
+	FRQ     byte   // 文件准备就绪限定词，BS位（FRQ_NEGATIVE）置位为否定确认
+}
+
+func (e MessageElement_120) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_120) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_120) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] NOF=1 LOF=1024 FRQ=0
+func (e MessageElement_120) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_120) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为F_FR_NA_1
+func (e *MessageElement_120) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, F_FR_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_120) Size() int {
+	return IOASize + 6
+}
+
+// TypeID 类型标识
+func (e MessageElement_120) TypeID() byte {
+	return F_FR_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_120) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_120) AppendElement(dst []byte) []byte {
+	dst = append(dst, byte(e.NOF), byte(e.NOF>>8))
+	dst = appendUint24(dst, e.LOF)
+	return append(dst, e.FRQ)
+}
+
+// Objects 信息对象
+func (e MessageElement_120) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseF_FR_NA_1(msgBody []byte) MessageElement_120 {
+	return MessageElement_120{
+		Address: parseIOA(msgBody),
+		NOF:     binary.LittleEndian.Uint16(msgBody[IOASize:]),
+		LOF:     parseUint24(msgBody[IOASize+2:]),
+		FRQ:     msgBody[IOASize+5],
+	}
+}
+
+// NewASDUF_FR_NA_1 创建文件准备就绪
+func NewASDUF_FR_NA_1(cause Cause, publicAddress uint16, address uint32, nof uint16, lof uint32, frq byte) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         F_FR_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_120{
+			Address: address,
+			NOF:     nof,
+			LOF:     lof,
+			FRQ:     frq,
+		},
+	}
+}
@@ -0,0 +1,102 @@
+package elements
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// MessageElement_123 最后的节，最后的段，《DLT 634.5101-2002》 7.3.6.4 123:F_LS_NA_1
+type MessageElement_123 struct {
+	Address uint32 // 信息对象地址
+	NOF     uint16 // 文件名称
+	NOS     byte   // 节名称
+	LSQ     byte   // 最后的节或段的限定词，LSQ_FILE等
+	CHS     byte   // 校验和
+}
+
+func (e MessageElement_123) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_123) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_123) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] NOF=1 NOS=1 LSQ=3 CHS=171
+func (e MessageElement_123) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_123) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为F_LS_NA_1
+func (e *MessageElement_123) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, F_LS_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_123) Size() int {
+	return IOASize + 5
+}
+
+// TypeID 类型标识
+func (e MessageElement_123) TypeID() byte {
+	return F_LS_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_123) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_123) AppendElement(dst []byte) []byte {
+	dst = append(dst, byte(e.NOF), byte(e.NOF>>8))
+	return append(dst, e.NOS, e.LSQ, e.CHS)
+}
+
+// Objects 信息对象
+func (e MessageElement_123) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseF_LS_NA_1(msgBody []byte) MessageElement_123 {
+	return MessageElement_123{
+		Address: parseIOA(msgBody),
+		NOF:     binary.LittleEndian.Uint16(msgBody[IOASize:]),
+		NOS:     msgBody[IOASize+2],
+		LSQ:     msgBody[IOASize+3],
+		CHS:     msgBody[IOASize+4],
+	}
+}
+
+// NewASDUF_LS_NA_1 创建最后的节，最后的段
+func NewASDUF_LS_NA_1(cause Cause, publicAddress uint16, address uint32, nof uint16, nos byte, lsq byte, chs byte) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         F_LS_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_123{
+			Address: address,
+			NOF:     nof,
+			NOS:     nos,
+			LSQ:     lsq,
+			CHS:     chs,
+		},
+	}
+}
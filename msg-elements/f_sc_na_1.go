@@ -0,0 +1,100 @@
+package elements
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// MessageElement_122 召唤目录，选择文件，召唤文件，召唤节，《DLT 634.5101-2002》 7.3.6.3 122:F_SC_NA_1
+type MessageElement_122 struct {
+	Address uint32        // 信息对象地址
+	NOF     uint16        // 文件名称
+	NOS     byte          // 节名称
+	SCQ     FileQualifier // 选择和召唤限定词
+}
+
+func (e MessageElement_122) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_122) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_122) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] NOF=1 NOS=0 SCQ=1
+func (e MessageElement_122) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_122) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为F_SC_NA_1
+func (e *MessageElement_122) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, F_SC_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_122) Size() int {
+	return IOASize + 4
+}
+
+// TypeID 类型标识
+func (e MessageElement_122) TypeID() byte {
+	return F_SC_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_122) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_122) AppendElement(dst []byte) []byte {
+	dst = append(dst, byte(e.NOF), byte(e.NOF>>8))
+	dst = append(dst, e.NOS)
+	return e.SCQ.AppendTo(dst)
+}
+
+// Objects 信息对象
+func (e MessageElement_122) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseF_SC_NA_1(msgBody []byte) MessageElement_122 {
+	return MessageElement_122{
+		Address: parseIOA(msgBody),
+		NOF:     binary.LittleEndian.Uint16(msgBody[IOASize:]),
+		NOS:     msgBody[IOASize+2],
+		SCQ:     ParseFileQualifier(msgBody[IOASize+3]),
+	}
+}
+
+// NewASDUF_SC_NA_1 创建召唤目录（cause为COT_REQ）或文件选择、召唤命令（cause为COT_FILE）
+func NewASDUF_SC_NA_1(cause Cause, publicAddress uint16, address uint32, nof uint16, nos byte, scq FileQualifier) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         F_SC_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_122{
+			Address: address,
+			NOF:     nof,
+			NOS:     nos,
+			SCQ:     scq,
+		},
+	}
+}
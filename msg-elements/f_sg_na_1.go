@@ -0,0 +1,111 @@
+package elements
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// MaxSegmentLen 104规约标准参数下一个段的最大长度（ASDU最大长度减去数据单元标识符、信息对象地址、NOF、NOS及LOS）
+const MaxSegmentLen = MaxASDULen - 6 - IOASize - 4
+
+// MessageElement_125 段，《DLT 634.5101-2002》 7.3.6.6 125:F_SG_NA_1
+type MessageElement_125 struct {
+	Address uint32 // 信息对象地址
+	NOF     uint16 // 文件名称
+	NOS     byte   // 节名称
+	Segment []byte // 段数据，长度即LOS，不超过 MaxSegmentLen
+}
+
+func (e MessageElement_125) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_125) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_125) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] NOF=1 NOS=1 Segment=[01 02 03]
+func (e MessageElement_125) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_125) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为F_SG_NA_1
+func (e *MessageElement_125) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, F_SG_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_125) Size() int {
+	return IOASize + 4 + len(e.Segment)
+}
+
+// TypeID 类型标识
+func (e MessageElement_125) TypeID() byte {
+	return F_SG_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_125) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_125) AppendElement(dst []byte) []byte {
+	dst = append(dst, byte(e.NOF), byte(e.NOF>>8), e.NOS, byte(len(e.Segment)))
+	return append(dst, e.Segment...)
+}
+
+// Objects 信息对象
+func (e MessageElement_125) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseF_SG_NA_1(msgBody []byte) MessageElement_125 {
+	los := int(msgBody[IOASize+3])
+	return MessageElement_125{
+		Address: parseIOA(msgBody),
+		NOF:     binary.LittleEndian.Uint16(msgBody[IOASize:]),
+		NOS:     msgBody[IOASize+2],
+		Segment: append([]byte(nil), msgBody[IOASize+4:IOASize+4+los]...),
+	}
+}
+
+// segmentSize 段的信息元素长度（不含信息对象地址），由body中的LOS确定，body不足时返回最小长度
+func segmentSize(body []byte) int {
+	if len(body) < IOASize+4 {
+		return 4
+	}
+	return 4 + int(body[IOASize+3])
+}
+
+// NewASDUF_SG_NA_1 创建段
+func NewASDUF_SG_NA_1(cause Cause, publicAddress uint16, address uint32, nof uint16, nos byte, segment []byte) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         F_SG_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_125{
+			Address: address,
+			NOF:     nof,
+			NOS:     nos,
+			Segment: segment,
+		},
+	}
+}
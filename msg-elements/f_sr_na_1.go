@@ -0,0 +1,104 @@
+package elements
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// MessageElement_121 节准备就绪，《DLT 634.5101-2002》 7.3.6.2 121:F_SR_NA_1
+type MessageElement_121 struct {
+	Address uint32 // 信息对象地址
+	NOF     uint16 // 文件名称
+	NOS     byte   // 节名称
+	LOS     uint32 // 节长度，3字节
+	SRQ     byte   // 节准备就绪限定词，BS位（SRQ_NOT_READY）置位为节未准备就绪
+}
+
+func (e MessageElement_121) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_121) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_121) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] NOF=1 NOS=1 LOS=1024 SRQ=0
+func (e MessageElement_121) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_121) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为F_SR_NA_1
+func (e *MessageElement_121) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, F_SR_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_121) Size() int {
+	return IOASize + 7
+}
+
+// TypeID 类型标识
+func (e MessageElement_121) TypeID() byte {
+	return F_SR_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_121) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_121) AppendElement(dst []byte) []byte {
+	dst = append(dst, byte(e.NOF), byte(e.NOF>>8))
+	dst = append(dst, e.NOS)
+	dst = appendUint24(dst, e.LOS)
+	return append(dst, e.SRQ)
+}
+
+// Objects 信息对象
+func (e MessageElement_121) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseF_SR_NA_1(msgBody []byte) MessageElement_121 {
+	return MessageElement_121{
+		Address: parseIOA(msgBody),
+		NOF:     binary.LittleEndian.Uint16(msgBody[IOASize:]),
+		NOS:     msgBody[IOASize+2],
+		LOS:     parseUint24(msgBody[IOASize+3:]),
+		SRQ:     msgBody[IOASize+6],
+	}
+}
+
+// NewASDUF_SR_NA_1 创建节准备就绪
+func NewASDUF_SR_NA_1(cause Cause, publicAddress uint16, address uint32, nof uint16, nos byte, los uint32, srq byte) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         F_SR_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_121{
+			Address: address,
+			NOF:     nof,
+			NOS:     nos,
+			LOS:     los,
+			SRQ:     srq,
+		},
+	}
+}
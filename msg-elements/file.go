@@ -0,0 +1,152 @@
+package elements
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// NOF 文件名称，《DLT 634.5101-2002》 7.2.6.33
+const (
+	NOF_DEFAULT     = 0 // 缺省
+	NOF_TRANSPARENT = 1 // 透明文件
+	NOF_DISTURBANCE = 2 // 扰动数据
+	NOF_SEQUENCE    = 3 // 事件顺序记录
+	NOF_RECORDED    = 4 // 记录的模拟值
+)
+
+// SCQ 选择和召唤限定词的命令，《DLT 634.5101-2002》 7.2.6.30
+const (
+	SCQ_DEFAULT            = 0 // 缺省，召唤目录
+	SCQ_SELECT_FILE        = 1 // 选择文件
+	SCQ_CALL_FILE          = 2 // 请求文件
+	SCQ_DEACTIVATE_FILE    = 3 // 停止激活文件
+	SCQ_DELETE_FILE        = 4 // 删除文件
+	SCQ_SELECT_SECTION     = 5 // 选择节
+	SCQ_CALL_SECTION       = 6 // 请求节
+	SCQ_DEACTIVATE_SECTION = 7 // 停止激活节
+)
+
+// AFQ 认可文件或节限定词的认可类型，《DLT 634.5101-2002》 7.2.6.32
+const (
+	AFQ_FILE_ACK     = 1 // 文件传输的肯定认可
+	AFQ_FILE_NACK    = 2 // 文件传输的否定认可
+	AFQ_SECTION_ACK  = 3 // 节传输的肯定认可
+	AFQ_SECTION_NACK = 4 // 节传输的否定认可
+)
+
+// 文件传输异常原因，SCQ及AFQ的高4位
+const (
+	FILE_ERR_NONE     = 0 // 缺省
+	FILE_ERR_MEMORY   = 1 // 无所请求的存储空间
+	FILE_ERR_CHECKSUM = 2 // 校验和错
+	FILE_ERR_SERVICE  = 3 // 非所期望的通信服务
+	FILE_ERR_FILE     = 4 // 非所期望的文件名称
+	FILE_ERR_SECTION  = 5 // 非所期望的节名称
+)
+
+// LSQ 最后的节或段的限定词，《DLT 634.5101-2002》 7.2.6.31
+const (
+	LSQ_FILE                = 1 // 不带停止激活的文件传输
+	LSQ_FILE_DEACTIVATED    = 2 // 带停止激活的文件传输
+	LSQ_SECTION             = 3 // 不带停止激活的节传输
+	LSQ_SECTION_DEACTIVATED = 4 // 带停止激活的节传输
+)
+
+const (
+	// FRQ_NEGATIVE 文件准备就绪限定词的BS位，置位表示选择、请求、停止激活或删除的否定确认
+	FRQ_NEGATIVE = 0x80
+	// SRQ_NOT_READY 节准备就绪限定词的BS位，置位表示节未准备就绪
+	SRQ_NOT_READY = 0x80
+)
+
+// FileQualifier 选择和召唤限定词（SCQ）及认可文件或节限定词（AFQ），《DLT 634.5101-2002》 7.2.6.30、7.2.6.32
+type FileQualifier struct {
+	Code byte // 低4位：SCQ为SCQ_SELECT_FILE等，AFQ为AFQ_FILE_ACK等
+	Err  byte // 高4位：异常原因，FILE_ERR_CHECKSUM等
+}
+
+// AppendTo 将编码结果追加到dst
+func (q FileQualifier) AppendTo(dst []byte) []byte {
+	return append(dst, q.Code&0x0F|q.Err<<4)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (q FileQualifier) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, q)
+}
+
+// ParseFileQualifier 解析SCQ或AFQ
+func ParseFileQualifier(b byte) FileQualifier {
+	return FileQualifier{Code: b & 0x0F, Err: b >> 4}
+}
+
+// String 如 3 ERR[2]，无异常原因时只有低4位
+func (q FileQualifier) String() string {
+	if q.Err == 0 {
+		return strconv.Itoa(int(q.Code))
+	}
+	return fmt.Sprintf("%d ERR[%d]", q.Code, q.Err)
+}
+
+// SOF 文件状态，《DLT 634.5101-2002》 7.2.6.38
+type SOF struct {
+	Status byte // 状态 0-31
+	LFD    bool // false(0) = 后面还有目录文件 | true(1) = 最后的目录文件
+	FOR    bool // false(0) = 定义文件名 | true(1) = 定义子目录名
+	FA     bool // false(0) = 文件等待传输 | true(1) = 此文件的传输已激活
+}
+
+// AppendTo 将编码结果追加到dst
+func (s SOF) AppendTo(dst []byte) []byte {
+	b := s.Status & 0x1F
+	if s.LFD {
+		b |= 0x20
+	}
+	if s.FOR {
+		b |= 0x40
+	}
+	if s.FA {
+		b |= 0x80
+	}
+	return append(dst, b)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (s SOF) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, s)
+}
+
+// ParseSOF 解析SOF
+func ParseSOF(b byte) SOF {
+	return SOF{
+		Status: b & 0x1F,
+		LFD:    b&0x20 != 0,
+		FOR:    b&0x40 != 0,
+		FA:     b&0x80 != 0,
+	}
+}
+
+// String 如 0 LFD|FA
+func (s SOF) String() string {
+	return fmt.Sprintf("%d %s", s.Status, flagString([]string{"LFD", "FOR", "FA"}, s.LFD, s.FOR, s.FA))
+}
+
+// Checksum 校验和（CHS），所有字节的算术和模256，《DLT 634.5101-2002》 7.2.6.37
+func Checksum(data []byte) byte {
+	var sum byte
+	for _, b := range data {
+		sum += b
+	}
+	return sum
+}
+
+// appendUint24 追加3字节小端无符号整数，用于文件或节的长度
+func appendUint24(dst []byte, v uint32) []byte {
+	return append(dst, byte(v), byte(v>>8), byte(v>>16))
+}
+
+// parseUint24 解析3字节小端无符号整数
+func parseUint24(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+}
@@ -0,0 +1,183 @@
+package elements
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var typeNames = map[byte]string{
+	M_BO_NA_1: "M_BO_NA_1",
+	M_ME_NA_1: "M_ME_NA_1",
+	M_ME_NB_1: "M_ME_NB_1",
+	M_ME_TB_1: "M_ME_TB_1",
+	M_ME_NC_1: "M_ME_NC_1",
+	M_IT_NA_1: "M_IT_NA_1",
+	M_PS_NA_1: "M_PS_NA_1",
+	M_ME_ND_1: "M_ME_ND_1",
+	M_BO_TB_1: "M_BO_TB_1",
+	M_ME_TE_1: "M_ME_TE_1",
+	M_EP_TD_1: "M_EP_TD_1",
+	M_EP_TE_1: "M_EP_TE_1",
+	M_EP_TF_1: "M_EP_TF_1",
+	C_SC_NA_1: "C_SC_NA_1",
+	C_DC_NA_1: "C_DC_NA_1",
+	C_RC_NA_1: "C_RC_NA_1",
+	C_SE_NA_1: "C_SE_NA_1",
+	C_SE_NB_1: "C_SE_NB_1",
+	C_SE_NC_1: "C_SE_NC_1",
+	C_BO_NA_1: "C_BO_NA_1",
+	C_SC_TA_1: "C_SC_TA_1",
+	C_DC_TA_1: "C_DC_TA_1",
+	C_RC_TA_1: "C_RC_TA_1",
+	C_SE_TA_1: "C_SE_TA_1",
+	C_SE_TB_1: "C_SE_TB_1",
+	C_SE_TC_1: "C_SE_TC_1",
+	C_BO_TA_1: "C_BO_TA_1",
+	M_EI_NA_1: "M_EI_NA_1",
+	C_IC_NA_1: "C_IC_NA_1",
+	C_CI_NA_1: "C_CI_NA_1",
+	C_RD_NA_1: "C_RD_NA_1",
+	C_CS_NA_1: "C_CS_NA_1",
+	C_TS_NA_1: "C_TS_NA_1",
+	C_RP_NA_1: "C_RP_NA_1",
+	C_TS_TA_1: "C_TS_TA_1",
+	P_ME_NA_1: "P_ME_NA_1",
+	P_ME_NB_1: "P_ME_NB_1",
+	P_ME_NC_1: "P_ME_NC_1",
+	P_AC_NA_1: "P_AC_NA_1",
+	F_FR_NA_1: "F_FR_NA_1",
+	F_SR_NA_1: "F_SR_NA_1",
+	F_SC_NA_1: "F_SC_NA_1",
+	F_LS_NA_1: "F_LS_NA_1",
+	F_AF_NA_1: "F_AF_NA_1",
+	F_SG_NA_1: "F_SG_NA_1",
+	F_DR_TA_1: "F_DR_TA_1",
+}
+
+// TypeName 类型标识助记符，如 M_ME_NC_1，未定义的类型返回空字符串
+func TypeName(typeID byte) string {
+	return typeNames[typeID]
+}
+
+// typeString 如 M_ME_NC_1(13)
+func typeString(typeID byte) string {
+	if name, ok := typeNames[typeID]; ok {
+		return fmt.Sprintf("%s(%d)", name, typeID)
+	}
+	return fmt.Sprintf("未定义(%d)", typeID)
+}
+
+// String 如 SQ=1 数目[2]
+func (v VSQ) String() string {
+	sq := 0
+	if v.SQ() {
+		sq = 1
+	}
+	return fmt.Sprintf("SQ=%d 数目[%d]", sq, v.Number())
+}
+
+// String 如 M_ME_NC_1(13) SQ=0 数目[2] spont(3) 公共地址[1]
+func (dui DUI) String() string {
+	return fmt.Sprintf("%s %v %v 公共地址[%d]", typeString(dui.TypeIdentification), dui.VSQ(), dui.COT, dui.CommonAddress())
+}
+
+// String 数据单元标识符及各信息对象，信息对象以分号分隔
+func (asdu ASDU) String() string {
+	if asdu.MessageBody == nil {
+		return asdu.DUI.String()
+	}
+	return fmt.Sprintf("%v: %v", asdu.DUI, asdu.MessageBody)
+}
+
+// String 各信息对象，以分号分隔
+func (l ObjectList) String() string {
+	return objectsString(l)
+}
+
+// String 各信息对象，以分号分隔
+func (s ObjectSequence) String() string {
+	return objectsString(s.Elements)
+}
+
+// Field 信息对象的一个字段，用于逐字段显示报文
+type Field struct {
+	Name  string // 字段名，信息对象地址为IOA
+	Value string // 字段值
+}
+
+// Fields 按字段展开信息对象或信息元素，信息对象地址（Address）命名为IOA，信息元素（Core）的字段直接展开
+//
+// v不是结构体时返回名为Value的单个字段。
+func Fields(v interface{}) []Field {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Struct {
+		return []Field{{Name: "Value", Value: fieldValue(rv)}}
+	}
+	var fields []Field
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		switch f.Name {
+		case "Address":
+			fields = append(fields, Field{Name: "IOA", Value: fmt.Sprint(rv.Field(i).Interface())})
+		case "Core":
+			fields = append(fields, Fields(rv.Field(i).Interface())...)
+		default:
+			fields = append(fields, Field{Name: f.Name, Value: fieldValue(rv.Field(i))})
+		}
+	}
+	return fields
+}
+
+// fieldValue 字段值，字节切片（如段数据）以十六进制显示
+func fieldValue(v reflect.Value) string {
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		return fmt.Sprintf("[% X]", v.Bytes())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// objectString 如 IOA[1] Value=1.5 QDS=IV|NT
+func objectString(v interface{}) string {
+	var b strings.Builder
+	for i, f := range Fields(v) {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		if f.Name == "IOA" {
+			fmt.Fprintf(&b, "IOA[%s]", f.Value)
+			continue
+		}
+		b.WriteString(f.Name)
+		b.WriteByte('=')
+		b.WriteString(f.Value)
+	}
+	return b.String()
+}
+
+// objectsString 各信息对象，以分号分隔
+func objectsString(objs []InformationObject) string {
+	parts := make([]string, len(objs))
+	for i, o := range objs {
+		parts[i] = fmt.Sprint(o)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// flagString 置位的标志名，以|分隔，均未置位时返回-
+func flagString(names []string, bits ...bool) string {
+	var set []string
+	for i, bit := range bits {
+		if bit {
+			set = append(set, names[i])
+		}
+	}
+	if len(set) == 0 {
+		return "-"
+	}
+	return strings.Join(set, "|")
+}
@@ -0,0 +1,45 @@
+package elements
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/wangxianzhuo/iec104/internal/frametest"
+)
+
+func FuzzParseASDU(f *testing.F) {
+	for _, frame := range frametest.Load(f, "testdata/asdu.txt") {
+		f.Add(frame)
+	}
+	params := []Params{
+		DefaultParams,
+		{CauseSize: 1, CommonAddrSize: 1},
+		{CauseSize: 1, CommonAddrSize: 2},
+	}
+	f.Fuzz(func(t *testing.T, input []byte) {
+		for _, p := range params {
+			// 宽松模式不能panic
+			lenient := p
+			lenient.Lenient = true
+			ParseASDUWithParams(input, lenient)
+
+			asdu, err := ParseASDUWithParams(input, p)
+			if err != nil {
+				var pe *ParseError
+				if !errors.As(err, &pe) {
+					t.Fatalf("解析异常[%v]不是ParseError", err)
+				}
+				continue
+			}
+			again, err := ParseASDUWithParams(asdu.ConvertBytes(), p)
+			if err != nil {
+				t.Fatalf("asdu[%X]重新编码为[%X]后解析异常: %v", input, asdu.ConvertBytes(), err)
+			}
+			// 浮点数可能为NaN，比较编码结果而不是解析结果
+			if !bytes.Equal(asdu.ConvertBytes(), again.ConvertBytes()) {
+				t.Fatalf("asdu[%X]编解码结果不一致: %v != %v", input, asdu, again)
+			}
+		}
+	})
+}
@@ -0,0 +1,440 @@
+package elements
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// JSON表示，用于转发解码后的报文及保存测试用例，可由JSON还原为相同的报文
+//
+// ASDU：
+//
+//	{
+//	  "type": "M_ME_NC_1",                   // 类型标识助记符，见 TypeName
+//	  "sq": false,                           // 可变结构限定词的SQ位，信息对象数目由objects确定
+//	  "cot": {"cause": 3, "negative": false, "test": false, "originator": 0},
+//	  "common_address": 1,                   // 公共地址
+//	  "cause_size": 2,                       // 传送原因长度，1或2
+//	  "common_address_size": 2,              // 公共地址长度，1或2
+//	  "objects": [ ... ]                     // 信息对象
+//	}
+//
+// 信息对象：
+//
+//	{"type": "M_ME_NC_1", "ioa": 16385, "value": 1.5, "qds": {"ov": false, "bl": false, "sb": false, "nt": false, "iv": true}}
+//
+// type为类型标识助记符，ioa为信息对象地址，其余字段为信息元素（如 MessageElementCore_13）各字段名的蛇形命名，
+// 如 QDS 为 qds、ParamsChanged 为 params_changed；品质描述词、命令限定词、时标等嵌套结构体同样以对象表示。
+// 规一化值（NVA）以[-1, 1)区间内的小数表示，段数据以十六进制字符串表示；
+// 短浮点数的NaN、正负无穷分别以字符串"NaN"、"+Inf"、"-Inf"表示。
+// 解析时缺少的字段取零值，未知字段返回异常。
+
+// objectTypes 各类型单个信息对象的结构体类型
+var objectTypes = map[byte]reflect.Type{
+	M_BO_NA_1: reflect.TypeOf(MessageElement_7_SQ_0_Ele{}),
+	M_ME_NA_1: reflect.TypeOf(MessageElement_9_SQ_0_Ele{}),
+	M_ME_NB_1: reflect.TypeOf(MessageElement_11_SQ_0_Ele{}),
+	M_ME_TB_1: reflect.TypeOf(MessageElement_12_SQ_0_Ele{}),
+	M_ME_NC_1: reflect.TypeOf(MessageElement_13_SQ_0_Ele{}),
+	M_IT_NA_1: reflect.TypeOf(MessageElement_15_SQ_0_Ele{}),
+	M_PS_NA_1: reflect.TypeOf(MessageElement_20_SQ_0_Ele{}),
+	M_ME_ND_1: reflect.TypeOf(MessageElement_21_SQ_0_Ele{}),
+	M_BO_TB_1: reflect.TypeOf(MessageElement_33_SQ_0_Ele{}),
+	M_ME_TE_1: reflect.TypeOf(MessageElement_35_SQ_0_Ele{}),
+	M_EP_TD_1: reflect.TypeOf(MessageElement_38_SQ_0_Ele{}),
+	M_EP_TE_1: reflect.TypeOf(MessageElement_39_SQ_0_Ele{}),
+	M_EP_TF_1: reflect.TypeOf(MessageElement_40_SQ_0_Ele{}),
+	C_SC_NA_1: reflect.TypeOf(MessageElement_45{}),
+	C_DC_NA_1: reflect.TypeOf(MessageElement_46{}),
+	C_RC_NA_1: reflect.TypeOf(MessageElement_47{}),
+	C_SE_NA_1: reflect.TypeOf(MessageElement_48{}),
+	C_SE_NB_1: reflect.TypeOf(MessageElement_49{}),
+	C_SE_NC_1: reflect.TypeOf(MessageElement_50{}),
+	C_BO_NA_1: reflect.TypeOf(MessageElement_51{}),
+	C_SC_TA_1: reflect.TypeOf(MessageElement_58{}),
+	C_DC_TA_1: reflect.TypeOf(MessageElement_59{}),
+	C_RC_TA_1: reflect.TypeOf(MessageElement_60{}),
+	C_SE_TA_1: reflect.TypeOf(MessageElement_61{}),
+	C_SE_TB_1: reflect.TypeOf(MessageElement_62{}),
+	C_SE_TC_1: reflect.TypeOf(MessageElement_63{}),
+	C_BO_TA_1: reflect.TypeOf(MessageElement_64{}),
+	M_EI_NA_1: reflect.TypeOf(MessageElement_70{}),
+	C_IC_NA_1: reflect.TypeOf(MessageElement_100{}),
+	C_CI_NA_1: reflect.TypeOf(MessageElement_101{}),
+	C_CS_NA_1: reflect.TypeOf(MessageElement_103{}),
+	C_TS_NA_1: reflect.TypeOf(MessageElement_104{}),
+	C_RP_NA_1: reflect.TypeOf(MessageElement_105{}),
+	C_TS_TA_1: reflect.TypeOf(MessageElement_107{}),
+	P_ME_NA_1: reflect.TypeOf(MessageElement_110{}),
+	P_ME_NB_1: reflect.TypeOf(MessageElement_111{}),
+	P_ME_NC_1: reflect.TypeOf(MessageElement_112{}),
+	P_AC_NA_1: reflect.TypeOf(MessageElement_113{}),
+	F_FR_NA_1: reflect.TypeOf(MessageElement_120{}),
+	F_SR_NA_1: reflect.TypeOf(MessageElement_121{}),
+	F_SC_NA_1: reflect.TypeOf(MessageElement_122{}),
+	F_LS_NA_1: reflect.TypeOf(MessageElement_123{}),
+	F_AF_NA_1: reflect.TypeOf(MessageElement_124{}),
+	F_SG_NA_1: reflect.TypeOf(MessageElement_125{}),
+	F_DR_TA_1: reflect.TypeOf(MessageElement_126_SQ_0_Ele{}),
+}
+
+var (
+	nvaType   = reflect.TypeOf(NVA(0))
+	bytesType = reflect.TypeOf([]byte(nil))
+)
+
+// typeByName 由助记符查找类型标识
+func typeByName(name string) (byte, error) {
+	for t, n := range typeNames {
+		if n == name {
+			if _, ok := objectTypes[t]; ok {
+				return t, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w[%s]", ErrUnknownTypeID, name)
+}
+
+// jsonField 有序JSON对象的一个字段
+type jsonField struct {
+	key   string
+	value interface{}
+}
+
+// jsonObject 按字段顺序编码的JSON对象
+type jsonObject []jsonField
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(f.key)
+		b.Write(key)
+		b.WriteByte(':')
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// snakeCase Go字段名转换为蛇形命名，如 ParamsChanged 为 params_changed、QDS 为 qds
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 &&
+			(unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// MarshalObject 将信息对象编码为带类型标识助记符的JSON
+func MarshalObject(o InformationObject) ([]byte, error) {
+	obj := jsonObject{{key: "type", value: TypeName(o.TypeID())}}
+	obj = appendFields(obj, reflect.ValueOf(o))
+	return json.Marshal(obj)
+}
+
+// appendFields 将结构体各字段追加到obj，Address命名为ioa，Core的字段直接展开
+func appendFields(obj jsonObject, rv reflect.Value) jsonObject {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		switch f.Name {
+		case "Address":
+			obj = append(obj, jsonField{key: "ioa", value: rv.Field(i).Interface()})
+		case "Core":
+			obj = appendFields(obj, rv.Field(i))
+		default:
+			obj = append(obj, jsonField{key: snakeCase(f.Name), value: jsonValue(rv.Field(i))})
+		}
+	}
+	return obj
+}
+
+// jsonValue 字段的JSON值
+func jsonValue(rv reflect.Value) interface{} {
+	switch {
+	case rv.Type() == nvaType:
+		return NVA(rv.Int()).Float()
+	case rv.Type() == bytesType:
+		return hex.EncodeToString(rv.Bytes())
+	case rv.Kind() == reflect.Struct:
+		return appendFields(nil, rv)
+	case rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64:
+		// JSON数字无法表示NaN和±Inf，以字符串代替
+		f := rv.Float()
+		switch {
+		case math.IsNaN(f):
+			return "NaN"
+		case math.IsInf(f, 1):
+			return "+Inf"
+		case math.IsInf(f, -1):
+			return "-Inf"
+		}
+		return rv.Interface()
+	default:
+		return rv.Interface()
+	}
+}
+
+// UnmarshalObject 由带类型标识助记符的JSON还原信息对象
+func UnmarshalObject(data []byte) (InformationObject, error) {
+	var head struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+	t, err := typeByName(head.Type)
+	if err != nil {
+		return nil, err
+	}
+	rv := reflect.New(objectTypes[t]).Elem()
+	if err := decodeObject(data, rv); err != nil {
+		return nil, err
+	}
+	return rv.Interface().(InformationObject), nil
+}
+
+// unmarshalObject 将JSON解析到类型为typeID的信息对象o，type字段须与typeID一致
+func unmarshalObject(data []byte, typeID byte, o interface{}) error {
+	var head struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return err
+	}
+	if head.Type != TypeName(typeID) {
+		return fmt.Errorf("信息对象类型[%s]应为[%s]", head.Type, TypeName(typeID))
+	}
+	return decodeObject(data, reflect.ValueOf(o).Elem())
+}
+
+// decodeObject 解析信息对象的各字段，未知字段返回异常
+func decodeObject(data []byte, rv reflect.Value) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	delete(fields, "type")
+	if err := decodeFields(fields, rv); err != nil {
+		return err
+	}
+	return unknownField(fields)
+}
+
+// decodeFields 由fields解析结构体各字段，已解析的字段从fields中删除
+func decodeFields(fields map[string]json.RawMessage, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		key := snakeCase(f.Name)
+		switch f.Name {
+		case "Address":
+			key = "ioa"
+		case "Core":
+			if err := decodeFields(fields, rv.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		raw, ok := fields[key]
+		if !ok {
+			continue
+		}
+		delete(fields, key)
+		if err := decodeValue(raw, rv.Field(i)); err != nil {
+			return fmt.Errorf("字段[%s]解析异常: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// unknownField 解析后剩余的字段为未知字段
+func unknownField(fields map[string]json.RawMessage) error {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	return fmt.Errorf("字段%v未知", keys)
+}
+
+// decodeValue 解析字段的JSON值
+func decodeValue(raw json.RawMessage, rv reflect.Value) error {
+	switch {
+	case rv.Type() == nvaType:
+		var f float64
+		if err := json.Unmarshal(raw, &f); err != nil {
+			return err
+		}
+		rv.SetInt(int64(NewNVA(f)))
+	case rv.Type() == bytesType:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return err
+		}
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return err
+		}
+		rv.SetBytes(b)
+	case rv.Kind() == reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return err
+		}
+		if err := decodeFields(fields, rv); err != nil {
+			return err
+		}
+		return unknownField(fields)
+	case rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64:
+		var s string
+		if json.Unmarshal(raw, &s) != nil {
+			return json.Unmarshal(raw, rv.Addr().Interface())
+		}
+		switch s {
+		case "NaN":
+			rv.SetFloat(math.NaN())
+		case "+Inf":
+			rv.SetFloat(math.Inf(1))
+		case "-Inf":
+			rv.SetFloat(math.Inf(-1))
+		default:
+			return fmt.Errorf("无效的浮点数[%s]", s)
+		}
+	default:
+		return json.Unmarshal(raw, rv.Addr().Interface())
+	}
+	return nil
+}
+
+// asduJSON ASDU的JSON表示
+type asduJSON struct {
+	Type              string            `json:"type"`
+	SQ                bool              `json:"sq"`
+	COT               cotJSON           `json:"cot"`
+	CommonAddress     uint16            `json:"common_address"`
+	CauseSize         int               `json:"cause_size"`
+	CommonAddressSize int               `json:"common_address_size"`
+	Objects           []json.RawMessage `json:"objects"`
+}
+
+type cotJSON struct {
+	Cause      Cause `json:"cause"`
+	Negative   bool  `json:"negative"`
+	Test       bool  `json:"test"`
+	Originator byte  `json:"originator"`
+}
+
+// MarshalJSON 编码为JSON，信息对象按 Objects 展开
+func (asdu ASDU) MarshalJSON() ([]byte, error) {
+	dui := asdu.DUI
+	name := TypeName(dui.TypeIdentification)
+	if _, ok := objectTypes[dui.TypeIdentification]; !ok {
+		return nil, &UnknownTypeError{TypeID: dui.TypeIdentification}
+	}
+	v := asduJSON{
+		Type:              name,
+		SQ:                dui.VSQ().SQ(),
+		COT:               cotJSON(dui.COT),
+		CommonAddress:     dui.CommonAddress(),
+		CauseSize:         1,
+		CommonAddressSize: 1,
+		Objects:           []json.RawMessage{},
+	}
+	if dui.CauseExtEnable {
+		v.CauseSize = 2
+	}
+	if dui.PublicAddressHigEnable {
+		v.CommonAddressSize = 2
+	}
+	for _, o := range asdu.Objects() {
+		b, err := MarshalObject(o)
+		if err != nil {
+			return nil, err
+		}
+		v.Objects = append(v.Objects, b)
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON 由JSON还原ASDU，信息体与解析同一报文的结果一致
+func (asdu *ASDU) UnmarshalJSON(data []byte) error {
+	var v asduJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	t, err := typeByName(v.Type)
+	if err != nil {
+		return err
+	}
+	p := Params{CauseSize: v.CauseSize, CommonAddrSize: v.CommonAddressSize}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if len(v.Objects) == 0 || len(v.Objects) > MaxObjects {
+		return fmt.Errorf("信息对象数目[%d]非法", len(v.Objects))
+	}
+
+	objs := make([]InformationObject, len(v.Objects))
+	for i, raw := range v.Objects {
+		o, err := UnmarshalObject(raw)
+		if err != nil {
+			return err
+		}
+		if o.TypeID() != t {
+			return fmt.Errorf("信息对象类型[%s]与ASDU类型[%s]不一致", TypeName(o.TypeID()), v.Type)
+		}
+		if v.SQ && i > 0 && o.IOA() != objs[0].IOA()+uint32(i) {
+			return fmt.Errorf("SQ=1时信息对象地址[%d]不连续", o.IOA())
+		}
+		objs[i] = o
+	}
+
+	dui := p.Apply(DUI{
+		TypeIdentification:         t,
+		VariableStructureQualifier: byte(NewVSQ(v.SQ, len(objs))),
+		COT:                        COT(v.COT),
+		PublicAddressLow:           byte(v.CommonAddress),
+		PublicAddressHig:           byte(v.CommonAddress >> 8),
+	})
+	var body BytesConverter = ObjectList(objs)
+	if v.SQ {
+		body = ObjectSequence{Address: objs[0].IOA(), Elements: objs}
+	}
+	// 编码后重新解析，得到与解析报文相同的信息体类型
+	parsed, err := ParseASDUWithParams(ASDU{DUI: dui, MessageBody: body}.ConvertBytes(), p)
+	if err != nil {
+		return err
+	}
+	*asdu = parsed
+	return nil
+}
@@ -0,0 +1,245 @@
+package elements
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	M_BO_NA_1_SQ_1_MSG_LEN = 5
+	M_BO_NA_1_SQ_0_MSG_LEN = 8
+)
+
+// MessageElement_7_SQ_1 32比特串，《DLT 634.5101-2002》 7.3.1.7 7:M_BO_NA_1，SQ=1的信息元素
+type MessageElement_7_SQ_1 struct {
+	Address uint32
+	Cores   []MessageElementCore_7
+}
+
+func (e MessageElement_7_SQ_1) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_7_SQ_1) AppendTo(dst []byte) []byte {
+	dst = appendIOA(dst, e.Address)
+	for _, c := range e.Cores {
+		dst = c.AppendTo(dst)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_7_SQ_1) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_7_SQ_1) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_7_SQ_1) Size() int {
+	return IOASize + len(e.Cores)*M_BO_NA_1_SQ_1_MSG_LEN
+}
+
+// Objects 按序号展开地址后的信息对象
+func (e MessageElement_7_SQ_1) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e.Cores))
+	for i, c := range e.Cores {
+		objs[i] = MessageElement_7_SQ_0_Ele{Address: e.Address + uint32(i), Core: c}
+	}
+	return objs
+}
+
+// MessageElement_7_SQ_0_Ele 32比特串，《DLT 634.5101-2002》 7.3.1.7 7:M_BO_NA_1，SQ=0的信息元素
+type MessageElement_7_SQ_0_Ele struct {
+	Address uint32
+	Core    MessageElementCore_7
+}
+
+func (e MessageElement_7_SQ_0_Ele) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_7_SQ_0_Ele) AppendTo(dst []byte) []byte {
+	return e.Core.AppendTo(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_7_SQ_0_Ele) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] Value=0x0000000F QDS=-
+func (e MessageElement_7_SQ_0_Ele) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_7_SQ_0_Ele) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为M_BO_NA_1
+func (e *MessageElement_7_SQ_0_Ele) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, M_BO_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_7_SQ_0_Ele) Size() int {
+	return M_BO_NA_1_SQ_0_MSG_LEN
+}
+
+// TypeID 类型标识
+func (e MessageElement_7_SQ_0_Ele) TypeID() byte {
+	return M_BO_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_7_SQ_0_Ele) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_7_SQ_0_Ele) AppendElement(dst []byte) []byte {
+	return e.Core.AppendTo(dst)
+}
+
+// Float 信息对象的值
+func (e MessageElement_7_SQ_0_Ele) Float() float64 {
+	return float64(e.Core.Value)
+}
+
+// Quality 品质描述词
+func (e MessageElement_7_SQ_0_Ele) Quality() QDS {
+	return e.Core.QDS
+}
+
+type MessageElement_7_SQ_0 []MessageElement_7_SQ_0_Ele
+
+func (e MessageElement_7_SQ_0) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_7_SQ_0) AppendTo(dst []byte) []byte {
+	for _, ele := range e {
+		dst = ele.AppendTo(dst)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_7_SQ_0) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_7_SQ_0) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_7_SQ_0) Size() int {
+	return len(e) * M_BO_NA_1_SQ_0_MSG_LEN
+}
+
+// Objects 信息对象
+func (e MessageElement_7_SQ_0) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e))
+	for i, ele := range e {
+		objs[i] = ele
+	}
+	return objs
+}
+
+// MessageElementCore_7 32比特串及品质描述词
+type MessageElementCore_7 struct {
+	Value BSI // 32比特串
+	QDS   QDS
+}
+
+func (c MessageElementCore_7) ConvertBytes() []byte {
+	return c.AppendTo(make([]byte, 0, M_BO_NA_1_SQ_1_MSG_LEN))
+}
+
+// AppendTo 将编码结果追加到dst
+func (c MessageElementCore_7) AppendTo(dst []byte) []byte {
+	return c.QDS.AppendTo(c.Value.AppendTo(dst))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (c MessageElementCore_7) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, c)
+}
+
+// String 如 Value=0x0000000F QDS=-
+func (c MessageElementCore_7) String() string {
+	return objectString(c)
+}
+
+func parseM_BO_NA_1(msgBody []byte, dui DUI) BytesConverter {
+	vsq := dui.VSQ()
+	number := vsq.Number()
+
+	switch {
+	case !vsq.SQ():
+		elements := make(MessageElement_7_SQ_0, 0, number)
+		for i := 0; i < number*M_BO_NA_1_SQ_0_MSG_LEN; i += M_BO_NA_1_SQ_0_MSG_LEN {
+			elements = append(elements, MessageElement_7_SQ_0_Ele{
+				Address: parseIOA(msgBody[i:]),
+				Core:    parseCore_7(msgBody[i+IOASize:]),
+			})
+		}
+		return elements
+	default:
+		elements := MessageElement_7_SQ_1{
+			Address: parseIOA(msgBody),
+			Cores:   make([]MessageElementCore_7, 0, number),
+		}
+		msgBody = msgBody[IOASize:]
+		for i := 0; i < number*M_BO_NA_1_SQ_1_MSG_LEN; i += M_BO_NA_1_SQ_1_MSG_LEN {
+			elements.Cores = append(elements.Cores, parseCore_7(msgBody[i:]))
+		}
+		return elements
+	}
+}
+
+func parseCore_7(b []byte) MessageElementCore_7 {
+	return MessageElementCore_7{
+		Value: ParseBSI(b),
+		QDS:   ParseQDS(b[4]),
+	}
+}
+
+// BSI 二进制状态信息（32比特串），《DLT 634.5101-2002》 7.2.6.13，第1位为最低位
+type BSI uint32
+
+// Bit 第i位（1-32）是否置位
+func (b BSI) Bit(i int) bool {
+	return i >= 1 && i <= 32 && b&(1<<(i-1)) != 0
+}
+
+// AppendTo 将编码结果追加到dst
+func (b BSI) AppendTo(dst []byte) []byte {
+	return append(dst, byte(b), byte(b>>8), byte(b>>16), byte(b>>24))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (b BSI) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, b)
+}
+
+// ParseBSI 解析BSI
+func ParseBSI(b []byte) BSI {
+	return BSI(binary.LittleEndian.Uint32(b[0:4]))
+}
+
+// String 十六进制，如 0x0000000F
+func (b BSI) String() string {
+	return fmt.Sprintf("0x%08X", uint32(b))
+}
@@ -0,0 +1,127 @@
+package elements
+
+import (
+	"fmt"
+	"io"
+)
+
+// COI 初始化原因，《DLT 634.5101-2002》 7.2.6.21
+const (
+	COI_LOCAL_POWER_ON = 0 // 当地电源合上
+	COI_LOCAL_RESET    = 1 // 当地手动复位
+	COI_REMOTE_RESET   = 2 // 远方复位
+)
+
+// COI 初始化原因，《DLT 634.5101-2002》 7.2.6.21
+type COI struct {
+	Cause         byte // 初始化原因 0-127，COI_LOCAL_POWER_ON等
+	ParamsChanged bool // false(0) = 未改变当地参数的初始化 | true(1) = 改变当地参数后的初始化
+}
+
+// AppendTo 将编码结果追加到dst
+func (c COI) AppendTo(dst []byte) []byte {
+	b := c.Cause & 0x7F
+	if c.ParamsChanged {
+		b |= 0x80
+	}
+	return append(dst, b)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (c COI) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, c)
+}
+
+// ParseCOI 解析COI
+func ParseCOI(b byte) COI {
+	return COI{
+		Cause:         b & 0x7F,
+		ParamsChanged: b&0x80 != 0,
+	}
+}
+
+// String 如 远方复位(2) 参数已改变
+func (c COI) String() string {
+	var s string
+	switch c.Cause {
+	case COI_LOCAL_POWER_ON:
+		s = "当地电源合上(0)"
+	case COI_LOCAL_RESET:
+		s = "当地手动复位(1)"
+	case COI_REMOTE_RESET:
+		s = "远方复位(2)"
+	default:
+		s = fmt.Sprintf("未定义(%d)", c.Cause)
+	}
+	if c.ParamsChanged {
+		s += " 参数已改变"
+	}
+	return s
+}
+
+// MessageElement_70 初始化结束，《DLT 634.5101-2002》 7.3.3.1 70:M_EI_NA_1
+type MessageElement_70 struct {
+	Address uint32 // 信息对象地址，初始化结束为0
+	COI     COI    // 初始化原因
+}
+
+func (e MessageElement_70) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_70) AppendTo(dst []byte) []byte {
+	return e.COI.AppendTo(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_70) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[0] COI=远方复位(2)
+func (e MessageElement_70) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_70) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为M_EI_NA_1
+func (e *MessageElement_70) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, M_EI_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_70) Size() int {
+	return IOASize + 1
+}
+
+// TypeID 类型标识
+func (e MessageElement_70) TypeID() byte {
+	return M_EI_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_70) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_70) AppendElement(dst []byte) []byte {
+	return e.COI.AppendTo(dst)
+}
+
+// Objects 信息对象
+func (e MessageElement_70) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseM_EI_NA_1(msgBody []byte) MessageElement_70 {
+	return MessageElement_70{
+		Address: parseIOA(msgBody),
+		COI:     ParseCOI(msgBody[IOASize]),
+	}
+}
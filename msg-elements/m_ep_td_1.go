@@ -0,0 +1,215 @@
+package elements
+
+import "io"
+
+const (
+	M_EP_TD_1_SQ_1_MSG_LEN = 10
+	M_EP_TD_1_SQ_0_MSG_LEN = 13
+)
+
+// MessageElement_38_SQ_1 带CP56Time2a时标的继电保护设备事件，《DLT 634.5101-2002》 7.3.1.30 38:M_EP_TD_1，SQ=1的信息元素
+type MessageElement_38_SQ_1 struct {
+	Address uint32
+	Cores   []MessageElementCore_38
+}
+
+func (e MessageElement_38_SQ_1) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_38_SQ_1) AppendTo(dst []byte) []byte {
+	dst = appendIOA(dst, e.Address)
+	for _, c := range e.Cores {
+		dst = c.AppendTo(dst)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_38_SQ_1) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_38_SQ_1) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_38_SQ_1) Size() int {
+	return IOASize + len(e.Cores)*M_EP_TD_1_SQ_1_MSG_LEN
+}
+
+// Objects 按序号展开地址后的信息对象
+func (e MessageElement_38_SQ_1) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e.Cores))
+	for i, c := range e.Cores {
+		objs[i] = MessageElement_38_SQ_0_Ele{Address: e.Address + uint32(i), Core: c}
+	}
+	return objs
+}
+
+// MessageElement_38_SQ_0_Ele 带CP56Time2a时标的继电保护设备事件，《DLT 634.5101-2002》 7.3.1.30 38:M_EP_TD_1，SQ=0的信息元素
+type MessageElement_38_SQ_0_Ele struct {
+	Address uint32
+	Core    MessageElementCore_38
+}
+
+func (e MessageElement_38_SQ_0_Ele) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_38_SQ_0_Ele) AppendTo(dst []byte) []byte {
+	return e.Core.AppendTo(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_38_SQ_0_Ele) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] SEP=合(2) - Elapsed=20ms Time=2024-01-02 03:04:05.678
+func (e MessageElement_38_SQ_0_Ele) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_38_SQ_0_Ele) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为M_EP_TD_1
+func (e *MessageElement_38_SQ_0_Ele) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, M_EP_TD_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_38_SQ_0_Ele) Size() int {
+	return M_EP_TD_1_SQ_0_MSG_LEN
+}
+
+// TypeID 类型标识
+func (e MessageElement_38_SQ_0_Ele) TypeID() byte {
+	return M_EP_TD_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_38_SQ_0_Ele) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_38_SQ_0_Ele) AppendElement(dst []byte) []byte {
+	return e.Core.AppendTo(dst)
+}
+
+type MessageElement_38_SQ_0 []MessageElement_38_SQ_0_Ele
+
+func (e MessageElement_38_SQ_0) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_38_SQ_0) AppendTo(dst []byte) []byte {
+	for _, ele := range e {
+		dst = ele.AppendTo(dst)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_38_SQ_0) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_38_SQ_0) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_38_SQ_0) Size() int {
+	return len(e) * M_EP_TD_1_SQ_0_MSG_LEN
+}
+
+// Objects 信息对象
+func (e MessageElement_38_SQ_0) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e))
+	for i, ele := range e {
+		objs[i] = ele
+	}
+	return objs
+}
+
+// MessageElementCore_38 继电保护设备单个事件、动作时间及时标
+type MessageElementCore_38 struct {
+	SEP     SEP
+	Elapsed CP16Time2a // 动作时间
+	Time    CP56Time2a
+}
+
+func (c MessageElementCore_38) ConvertBytes() []byte {
+	return c.AppendTo(make([]byte, 0, M_EP_TD_1_SQ_1_MSG_LEN))
+}
+
+// AppendTo 将编码结果追加到dst
+func (c MessageElementCore_38) AppendTo(dst []byte) []byte {
+	return c.Time.AppendTo(c.Elapsed.AppendTo(c.SEP.AppendTo(dst)))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (c MessageElementCore_38) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, c)
+}
+
+// String 如 SEP=合(2) - Elapsed=20ms Time=2024-01-02 03:04:05.678
+func (c MessageElementCore_38) String() string {
+	return objectString(c)
+}
+
+func parseM_EP_TD_1(msgBody []byte, dui DUI) BytesConverter {
+	vsq := dui.VSQ()
+	number := vsq.Number()
+
+	switch {
+	case !vsq.SQ():
+		elements := make(MessageElement_38_SQ_0, 0, number)
+		for i := 0; i < number*M_EP_TD_1_SQ_0_MSG_LEN; i += M_EP_TD_1_SQ_0_MSG_LEN {
+			elements = append(elements, MessageElement_38_SQ_0_Ele{
+				Address: parseIOA(msgBody[i:]),
+				Core:    parseCore_38(msgBody[i+IOASize:]),
+			})
+		}
+		return elements
+	default:
+		elements := MessageElement_38_SQ_1{
+			Address: parseIOA(msgBody),
+			Cores:   make([]MessageElementCore_38, 0, number),
+		}
+		msgBody = msgBody[IOASize:]
+		for i := 0; i < number*M_EP_TD_1_SQ_1_MSG_LEN; i += M_EP_TD_1_SQ_1_MSG_LEN {
+			elements.Cores = append(elements.Cores, parseCore_38(msgBody[i:]))
+		}
+		return elements
+	}
+}
+
+func parseCore_38(b []byte) MessageElementCore_38 {
+	return MessageElementCore_38{
+		SEP:     ParseSEP(b[0]),
+		Elapsed: ParseCP16Time2a(b[1:]),
+		Time:    ParseCP56Time2a(b[3:]),
+	}
+}
+
+// EventTime 事件发生时间
+func (e MessageElement_38_SQ_0_Ele) EventTime() CP56Time2a {
+	return e.Core.Time
+}
+
+// Elapsed 动作时间
+func (e MessageElement_38_SQ_0_Ele) Elapsed() CP16Time2a {
+	return e.Core.Elapsed
+}
@@ -0,0 +1,235 @@
+package elements
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	M_IT_NA_1_SQ_1_MSG_LEN = 5
+	M_IT_NA_1_SQ_0_MSG_LEN = 8
+)
+
+// MessageElement_15_SQ_1 累计量，《DLT 634.5101-2002》 7.3.1.15 15:M_IT_NA_1，SQ=1的信息元素
+type MessageElement_15_SQ_1 struct {
+	Address uint32
+	Cores   []BCR
+}
+
+func (e MessageElement_15_SQ_1) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_15_SQ_1) AppendTo(dst []byte) []byte {
+	dst = appendIOA(dst, e.Address)
+	for _, c := range e.Cores {
+		dst = c.AppendTo(dst)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_15_SQ_1) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_15_SQ_1) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_15_SQ_1) Size() int {
+	return IOASize + len(e.Cores)*M_IT_NA_1_SQ_1_MSG_LEN
+}
+
+// Objects 按序号展开地址后的信息对象
+func (e MessageElement_15_SQ_1) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e.Cores))
+	for i, c := range e.Cores {
+		objs[i] = MessageElement_15_SQ_0_Ele{Address: e.Address + uint32(i), Core: c}
+	}
+	return objs
+}
+
+// MessageElement_15_SQ_0_Ele 累计量，《DLT 634.5101-2002》 7.3.1.15 15:M_IT_NA_1，SQ=0的信息元素
+type MessageElement_15_SQ_0_Ele struct {
+	Address uint32
+	Core    BCR
+}
+
+func (e MessageElement_15_SQ_0_Ele) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_15_SQ_0_Ele) AppendTo(dst []byte) []byte {
+	return e.Core.AppendTo(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_15_SQ_0_Ele) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] Counter=1024 SQ=3 CY=false CA=false IV=false
+func (e MessageElement_15_SQ_0_Ele) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_15_SQ_0_Ele) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为M_IT_NA_1
+func (e *MessageElement_15_SQ_0_Ele) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, M_IT_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_15_SQ_0_Ele) Size() int {
+	return M_IT_NA_1_SQ_0_MSG_LEN
+}
+
+// TypeID 类型标识
+func (e MessageElement_15_SQ_0_Ele) TypeID() byte {
+	return M_IT_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_15_SQ_0_Ele) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_15_SQ_0_Ele) AppendElement(dst []byte) []byte {
+	return e.Core.AppendTo(dst)
+}
+
+// Float 信息对象的值
+func (e MessageElement_15_SQ_0_Ele) Float() float64 {
+	return float64(e.Core.Counter)
+}
+
+// Quality 品质描述词，仅IV位有效
+func (e MessageElement_15_SQ_0_Ele) Quality() QDS {
+	return QDS{IV: e.Core.IV}
+}
+
+type MessageElement_15_SQ_0 []MessageElement_15_SQ_0_Ele
+
+func (e MessageElement_15_SQ_0) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_15_SQ_0) AppendTo(dst []byte) []byte {
+	for _, ele := range e {
+		dst = ele.AppendTo(dst)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_15_SQ_0) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_15_SQ_0) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_15_SQ_0) Size() int {
+	return len(e) * M_IT_NA_1_SQ_0_MSG_LEN
+}
+
+// Objects 信息对象
+func (e MessageElement_15_SQ_0) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e))
+	for i, ele := range e {
+		objs[i] = ele
+	}
+	return objs
+}
+
+// BCR 二进制计数器读数，《DLT 634.5101-2002》 7.2.6.9
+type BCR struct {
+	Counter int32 // 计数器读数
+	SQ      byte  // 顺序号 0-31
+	CY      bool  // false(0) = 未溢出 | true(1) = 溢出
+	CA      bool  // false(0) = 上次读数后计数器未被调整 | true(1) = 被调整
+	IV      bool  // false(0) = 有效 | true(1) = 无效
+}
+
+func (c BCR) ConvertBytes() []byte {
+	return c.AppendTo(make([]byte, 0, M_IT_NA_1_SQ_1_MSG_LEN))
+}
+
+// AppendTo 将编码结果追加到dst
+func (c BCR) AppendTo(dst []byte) []byte {
+	v := uint32(c.Counter)
+	flags := c.SQ & 0x1F
+	if c.CY {
+		flags |= 0x20
+	}
+	if c.CA {
+		flags |= 0x40
+	}
+	if c.IV {
+		flags |= 0x80
+	}
+	return append(dst, byte(v), byte(v>>8), byte(v>>16), byte(v>>24), flags)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (c BCR) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, c)
+}
+
+// ParseBCR 解析BCR
+func ParseBCR(bcr []byte) BCR {
+	return BCR{
+		Counter: int32(binary.LittleEndian.Uint32(bcr[0:4])),
+		SQ:      bcr[4] & 0x1F,
+		CY:      bcr[4]&0x20 != 0,
+		CA:      bcr[4]&0x40 != 0,
+		IV:      bcr[4]&0x80 != 0,
+	}
+}
+
+// String 如 1024 SQ[3] CY|IV
+func (c BCR) String() string {
+	return fmt.Sprintf("%d SQ[%d] %s", c.Counter, c.SQ, flagString([]string{"CY", "CA", "IV"}, c.CY, c.CA, c.IV))
+}
+
+func parseM_IT_NA_1(msgBody []byte, dui DUI) BytesConverter {
+	vsq := dui.VSQ()
+	number := vsq.Number()
+
+	switch {
+	case !vsq.SQ():
+		elements := make(MessageElement_15_SQ_0, 0, number)
+		for i := 0; i < number*M_IT_NA_1_SQ_0_MSG_LEN; i += M_IT_NA_1_SQ_0_MSG_LEN {
+			elements = append(elements, MessageElement_15_SQ_0_Ele{
+				Address: parseIOA(msgBody[i:]),
+				Core:    ParseBCR(msgBody[i+IOASize:]),
+			})
+		}
+		return elements
+	default:
+		elements := MessageElement_15_SQ_1{
+			Address: parseIOA(msgBody),
+			Cores:   make([]BCR, 0, number),
+		}
+		msgBody = msgBody[IOASize:]
+		for i := 0; i < number*M_IT_NA_1_SQ_1_MSG_LEN; i += M_IT_NA_1_SQ_1_MSG_LEN {
+			elements.Cores = append(elements.Cores, ParseBCR(msgBody[i:]))
+		}
+		return elements
+	}
+}
@@ -1,104 +1,221 @@
 package elements
 
 import (
-	"bytes"
 	"encoding/binary"
 	"fmt"
+	"io"
+	"math"
+	"strconv"
 )
 
-// MessageElement_9_SQ_1 测量值，短浮点数，《DLT 634.5101-2002》 7.3.1.13 13:M_ME_NC_1，SQ=1的信息元素
+const (
+	M_ME_NA_1_SQ_1_MSG_LEN = 3
+	M_ME_NA_1_SQ_0_MSG_LEN = 6
+)
+
+// MessageElement_9_SQ_1 测量值，规一化值，《DLT 634.5101-2002》 7.3.1.9 9:M_ME_NA_1，SQ=1的信息元素
 type MessageElement_9_SQ_1 struct {
 	Address uint32
 	Cores   []MessageElementCore_9
 }
 
 func (e MessageElement_9_SQ_1) ConvertBytes() []byte {
-	var cores []byte
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_9_SQ_1) AppendTo(dst []byte) []byte {
+	dst = appendIOA(dst, e.Address)
 	for _, c := range e.Cores {
-		cores = append(cores, c.ConvertBytes()...)
+		dst = c.AppendTo(dst)
 	}
-	return append([]byte{byte(e.Address & 0xFF), byte(e.Address >> 8 & 0xFF), byte(e.Address >> 16 & 0xFF)}, cores...)
+	return dst
 }
 
-// MessageElement_9_SQ_0_Ele 测量值，短浮点数，《DLT 634.5101-2002》 7.3.1.13 13:M_ME_NC_1，SQ=0的信息元素
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_9_SQ_1) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_9_SQ_1) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_9_SQ_1) Size() int {
+	return IOASize + len(e.Cores)*M_ME_NA_1_SQ_1_MSG_LEN
+}
+
+// Objects 按序号展开地址后的信息对象
+func (e MessageElement_9_SQ_1) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e.Cores))
+	for i, c := range e.Cores {
+		objs[i] = MessageElement_9_SQ_0_Ele{Address: e.Address + uint32(i), Core: c}
+	}
+	return objs
+}
+
+// MessageElement_9_SQ_0_Ele 测量值，规一化值，《DLT 634.5101-2002》 7.3.1.9 9:M_ME_NA_1，SQ=0的信息元素
 type MessageElement_9_SQ_0_Ele struct {
 	Address uint32
 	Core    MessageElementCore_9
 }
 
 func (e MessageElement_9_SQ_0_Ele) ConvertBytes() []byte {
-	return append([]byte{byte(e.Address & 0xFF), byte(e.Address)}, e.Core.ConvertBytes()...)
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_9_SQ_0_Ele) AppendTo(dst []byte) []byte {
+	return e.Core.AppendTo(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_9_SQ_0_Ele) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] Value=0.5 QDS=-
+func (e MessageElement_9_SQ_0_Ele) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_9_SQ_0_Ele) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为M_ME_NA_1
+func (e *MessageElement_9_SQ_0_Ele) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, M_ME_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_9_SQ_0_Ele) Size() int {
+	return M_ME_NA_1_SQ_0_MSG_LEN
+}
+
+// TypeID 类型标识
+func (e MessageElement_9_SQ_0_Ele) TypeID() byte {
+	return M_ME_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_9_SQ_0_Ele) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_9_SQ_0_Ele) AppendElement(dst []byte) []byte {
+	return e.Core.AppendTo(dst)
+}
+
+// Float 信息对象的值，规一化值对应的小数
+func (e MessageElement_9_SQ_0_Ele) Float() float64 {
+	return e.Core.Value.Float()
+}
+
+// Quality 品质描述词
+func (e MessageElement_9_SQ_0_Ele) Quality() QDS {
+	return e.Core.QDS
 }
 
 type MessageElement_9_SQ_0 []MessageElement_9_SQ_0_Ele
 
 func (e MessageElement_9_SQ_0) ConvertBytes() []byte {
-	var result []byte
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_9_SQ_0) AppendTo(dst []byte) []byte {
 	for _, ele := range e {
-		result = append(result, ele.ConvertBytes()...)
+		dst = ele.AppendTo(dst)
 	}
-	return result
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_9_SQ_0) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_9_SQ_0) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_9_SQ_0) Size() int {
+	return len(e) * M_ME_NA_1_SQ_0_MSG_LEN
+}
+
+// Objects 信息对象
+func (e MessageElement_9_SQ_0) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e))
+	for i, ele := range e {
+		objs[i] = ele
+	}
+	return objs
 }
 
 type MessageElementCore_9 struct {
-	Value int16 // 规一化值
+	Value NVA // 规一化值
 	QDS   QDS
 }
 
 func (c MessageElementCore_9) ConvertBytes() []byte {
-	bytesBuffer := bytes.NewBuffer([]byte{})
-	binary.Write(bytesBuffer, binary.LittleEndian, int16(c.Value))
-	v := bytesBuffer.Bytes()
-	return append([]byte{
-		v[0],
-		v[1],
-	}, c.QDS.ConvertBytes()...)
-}
-
-func parseM_ME_NA_1(asdu []byte, dui DUI) (BytesConverter, error) {
-	// fmt.Printf("解析asdu: % X\n", asdu)
-	sq := dui.VariableStructureQualifier >> 7
-	number := dui.VariableStructureQualifier & 0x7F
-
-	switch sq {
-	case 0:
-		size := len(asdu[6:len(asdu)]) / int(number)
-		msgBody := asdu[6:len(asdu)]
-		var elements MessageElement_9_SQ_0
-		for i := 0; i < int(number)*size; i += size {
-			// fmt.Printf("msgBody: % X\n", msgBody)
-			address := binary.LittleEndian.Uint32(append([]byte{msgBody[i], msgBody[i+1], msgBody[i+2]}, 0x00))
-			// value := math.Float32frombits(binary.LittleEndian.Uint32(msgBody[i+3 : i+7]))
-			value, _ := getValueWithComplementUseLittleEndian(msgBody[i+3 : i+5])
-			// fmt.Printf("raw: %X, value: %v\n", msgBody[i+3:i+7], math.Float32frombits(binary.LittleEndian.Uint32(msgBody[i+3:i+7])))
-			qds := ParseQDS(msgBody[i+5])
-			element := MessageElement_9_SQ_0_Ele{
-				Address: address,
-				Core: MessageElementCore_9{
-					Value: value,
-					QDS:   qds,
-				},
-			}
-			elements = append(elements, element)
+	return c.AppendTo(make([]byte, 0, M_ME_NA_1_SQ_1_MSG_LEN))
+}
+
+// AppendTo 将编码结果追加到dst
+func (c MessageElementCore_9) AppendTo(dst []byte) []byte {
+	dst = append(dst, byte(c.Value), byte(c.Value>>8))
+	return c.QDS.AppendTo(dst)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (c MessageElementCore_9) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, c)
+}
+
+// String 如 Value=0.5 QDS=-
+func (c MessageElementCore_9) String() string {
+	return objectString(c)
+}
+
+func parseM_ME_NA_1(msgBody []byte, dui DUI) BytesConverter {
+	vsq := dui.VSQ()
+	number := vsq.Number()
+
+	switch {
+	case !vsq.SQ():
+		elements := make(MessageElement_9_SQ_0, 0, number)
+		for i := 0; i < number*M_ME_NA_1_SQ_0_MSG_LEN; i += M_ME_NA_1_SQ_0_MSG_LEN {
+			elements = append(elements, MessageElement_9_SQ_0_Ele{
+				Address: parseIOA(msgBody[i:]),
+				Core:    parseCore_9(msgBody[i+IOASize:]),
+			})
 		}
-		return elements, nil
+		return elements
 	default:
-		address := binary.LittleEndian.Uint32(append([]byte{asdu[6], asdu[7], asdu[8]}, 0x00))
-		msgBody := asdu[9:len(asdu)]
-		size := len(asdu[9:len(asdu)]) / int(number)
-		var elements MessageElement_9_SQ_1
-		elements.Address = address
-		for i := 0; i < int(number)*size; i += size {
-			// value := math.Float32frombits(binary.LittleEndian.Uint32(msgBody[i : i+4]))
-			value, _ := getValueWithComplementUseLittleEndian(msgBody[i : i+2])
-			qds := ParseQDS(msgBody[i+2])
-			core := MessageElementCore_9{
-				Value: value,
-				QDS:   qds,
-			}
-			elements.Cores = append(elements.Cores, core)
+		elements := MessageElement_9_SQ_1{
+			Address: parseIOA(msgBody),
+			Cores:   make([]MessageElementCore_9, 0, number),
+		}
+		msgBody = msgBody[IOASize:]
+		for i := 0; i < number*M_ME_NA_1_SQ_1_MSG_LEN; i += M_ME_NA_1_SQ_1_MSG_LEN {
+			elements.Cores = append(elements.Cores, parseCore_9(msgBody[i:]))
 		}
-		return elements, nil
+		return elements
+	}
+}
+
+func parseCore_9(b []byte) MessageElementCore_9 {
+	value, _ := getValueWithComplementUseLittleEndian(b[0:2])
+	return MessageElementCore_9{
+		Value: NVA(value),
+		QDS:   ParseQDS(b[2]),
 	}
 }
 
@@ -115,3 +232,28 @@ func getValueWithComplementUseLittleEndian(int16Bytes []byte) (int16, error) {
 		return int16(binary.LittleEndian.Uint16(int16Bytes)), nil
 	}
 }
+
+// NVA 规一化值，《DLT 634.5101-2002》 7.2.6.6，表示[-1, 1)区间内的小数，分辨率为2^-15
+type NVA int16
+
+// NewNVA 由小数f创建规一化值，超出范围时取边界值
+func NewNVA(f float64) NVA {
+	switch {
+	case f >= 1:
+		return math.MaxInt16
+	case f <= -1:
+		return math.MinInt16
+	default:
+		return NVA(math.Round(f * 32768))
+	}
+}
+
+// Float 规一化值对应的小数
+func (v NVA) Float() float64 {
+	return float64(v) / 32768
+}
+
+// String 对应的小数
+func (v NVA) String() string {
+	return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+}
@@ -2,6 +2,7 @@ package elements
 
 import (
 	"encoding/binary"
+	"io"
 	"math"
 )
 
@@ -17,11 +18,40 @@ type MessageElement_13_SQ_1 struct {
 }
 
 func (e MessageElement_13_SQ_1) ConvertBytes() []byte {
-	var cores []byte
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_13_SQ_1) AppendTo(dst []byte) []byte {
+	dst = appendIOA(dst, e.Address)
 	for _, c := range e.Cores {
-		cores = append(cores, c.ConvertBytes()...)
+		dst = c.AppendTo(dst)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_13_SQ_1) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_13_SQ_1) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_13_SQ_1) Size() int {
+	return IOASize + len(e.Cores)*M_ME_NC_1_SQ_1_MSG_LEN
+}
+
+// Objects 按序号展开地址后的信息对象
+func (e MessageElement_13_SQ_1) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e.Cores))
+	for i, c := range e.Cores {
+		objs[i] = MessageElement_13_SQ_0_Ele{Address: e.Address + uint32(i), Core: c}
 	}
-	return append([]byte{byte(e.Address & 0xFF), byte(e.Address >> 8 & 0xFF), byte(e.Address >> 16 & 0xFF)}, cores...)
+	return objs
 }
 
 // MessageElement_13_SQ_0_Ele 测量值，短浮点数，《DLT 634.5101-2002》 7.3.1.13 13:M_ME_NC_1，SQ=0的信息元素
@@ -31,17 +61,100 @@ type MessageElement_13_SQ_0_Ele struct {
 }
 
 func (e MessageElement_13_SQ_0_Ele) ConvertBytes() []byte {
-	return append([]byte{byte(e.Address & 0xFF), byte(e.Address)}, e.Core.ConvertBytes()...)
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_13_SQ_0_Ele) AppendTo(dst []byte) []byte {
+	return e.Core.AppendTo(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_13_SQ_0_Ele) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] Value=1.5 QDS=IV
+func (e MessageElement_13_SQ_0_Ele) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_13_SQ_0_Ele) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为M_ME_NC_1
+func (e *MessageElement_13_SQ_0_Ele) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, M_ME_NC_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_13_SQ_0_Ele) Size() int {
+	return M_ME_NC_1_SQ_0_MSG_LEN
+}
+
+// TypeID 类型标识
+func (e MessageElement_13_SQ_0_Ele) TypeID() byte {
+	return M_ME_NC_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_13_SQ_0_Ele) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_13_SQ_0_Ele) AppendElement(dst []byte) []byte {
+	return e.Core.AppendTo(dst)
+}
+
+// Float 信息对象的值
+func (e MessageElement_13_SQ_0_Ele) Float() float64 {
+	return float64(e.Core.Value)
+}
+
+// Quality 品质描述词
+func (e MessageElement_13_SQ_0_Ele) Quality() QDS {
+	return e.Core.QDS
 }
 
 type MessageElement_13_SQ_0 []MessageElement_13_SQ_0_Ele
 
 func (e MessageElement_13_SQ_0) ConvertBytes() []byte {
-	var result []byte
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_13_SQ_0) AppendTo(dst []byte) []byte {
 	for _, ele := range e {
-		result = append(result, ele.ConvertBytes()...)
+		dst = ele.AppendTo(dst)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_13_SQ_0) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_13_SQ_0) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_13_SQ_0) Size() int {
+	return len(e) * M_ME_NC_1_SQ_0_MSG_LEN
+}
+
+// Objects 信息对象
+func (e MessageElement_13_SQ_0) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e))
+	for i, ele := range e {
+		objs[i] = ele
 	}
-	return result
+	return objs
 }
 
 type MessageElementCore_13 struct {
@@ -50,13 +163,24 @@ type MessageElementCore_13 struct {
 }
 
 func (c MessageElementCore_13) ConvertBytes() []byte {
+	return c.AppendTo(make([]byte, 0, M_ME_NC_1_SQ_1_MSG_LEN))
+}
+
+// AppendTo 将编码结果追加到dst
+func (c MessageElementCore_13) AppendTo(dst []byte) []byte {
 	v := math.Float32bits(c.Value)
-	return append([]byte{
-		byte(v >> 24),
-		byte(v >> 16),
-		byte(v >> 8),
-		byte(v),
-	}, c.QDS.ConvertBytes()...)
+	dst = append(dst, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+	return c.QDS.AppendTo(dst)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (c MessageElementCore_13) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, c)
+}
+
+// String 如 Value=1.5 QDS=IV
+func (c MessageElementCore_13) String() string {
+	return objectString(c)
 }
 
 // QDS 品质描述词，《DLT 634.5101-2002》 7.2.6.3
@@ -64,11 +188,16 @@ type QDS struct {
 	OV bool // false(0) = 未溢出 | true(1) = 溢出
 	BL bool // false(0) = 未被锁闭 | true(1) = 被锁闭
 	SB bool // false(0) = 未被取代 | true(1) = 被取代
-	NT bool // false(0) = 非当前值 | true(1) = 当前值
-	IV bool // false(0) = 无效 | true(1) = 有效
+	NT bool // false(0) = 当前值 | true(1) = 非当前值
+	IV bool // false(0) = 有效 | true(1) = 无效
 }
 
 func (qds QDS) ConvertBytes() []byte {
+	return qds.AppendTo(make([]byte, 0, 1))
+}
+
+// AppendTo 将编码结果追加到dst
+func (qds QDS) AppendTo(dst []byte) []byte {
 	var result byte = 0x00
 	if qds.OV {
 		result += 0x01
@@ -85,63 +214,60 @@ func (qds QDS) ConvertBytes() []byte {
 	if qds.IV {
 		result += 0x80
 	}
-	return []byte{
-		result,
-	}
+	return append(dst, result)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (qds QDS) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, qds)
 }
 
-func parseM_ME_NC_1(asdu []byte, dui DUI) (BytesConverter, error) {
-	// fmt.Printf("解析asdu: % X\n", asdu)
-	sq := dui.VariableStructureQualifier >> 7
-	number := dui.VariableStructureQualifier & 0x7F
-
-	switch sq {
-	case 0:
-		size := len(asdu[6:len(asdu)]) / int(number)
-		msgBody := asdu[6:len(asdu)]
-		var elements MessageElement_13_SQ_0
-		for i := 0; i < int(number)*size; i += size {
-			// fmt.Printf("msgBody: % X\n", msgBody)
-			address := binary.LittleEndian.Uint32(append([]byte{msgBody[i], msgBody[i+1], msgBody[i+2]}, 0x00))
-			value := math.Float32frombits(binary.LittleEndian.Uint32(msgBody[i+3 : i+7]))
-			// fmt.Printf("raw: %X, value: %v\n", msgBody[i+3:i+7], math.Float32frombits(binary.LittleEndian.Uint32(msgBody[i+3:i+7])))
-			qds := ParseQDS(msgBody[i+7])
-			element := MessageElement_13_SQ_0_Ele{
-				Address: address,
-				Core: MessageElementCore_13{
-					Value: value,
-					QDS:   qds,
-				},
-			}
-			elements = append(elements, element)
+func parseM_ME_NC_1(msgBody []byte, dui DUI) BytesConverter {
+	vsq := dui.VSQ()
+	number := vsq.Number()
+
+	switch {
+	case !vsq.SQ():
+		elements := make(MessageElement_13_SQ_0, 0, number)
+		for i := 0; i < number*M_ME_NC_1_SQ_0_MSG_LEN; i += M_ME_NC_1_SQ_0_MSG_LEN {
+			elements = append(elements, MessageElement_13_SQ_0_Ele{
+				Address: parseIOA(msgBody[i:]),
+				Core:    parseCore_13(msgBody[i+IOASize:]),
+			})
 		}
-		return elements, nil
+		return elements
 	default:
-		address := binary.LittleEndian.Uint32(append([]byte{asdu[6], asdu[7], asdu[8]}, 0x00))
-		msgBody := asdu[9:len(asdu)]
-		size := len(asdu[9:len(asdu)]) / int(number)
-		var elements MessageElement_13_SQ_1
-		elements.Address = address
-		for i := 0; i < int(number)*size; i += size {
-			value := math.Float32frombits(binary.LittleEndian.Uint32(msgBody[i : i+4]))
-			qds := ParseQDS(msgBody[i+4])
-			core := MessageElementCore_13{
-				Value: value,
-				QDS:   qds,
-			}
-			elements.Cores = append(elements.Cores, core)
+		elements := MessageElement_13_SQ_1{
+			Address: parseIOA(msgBody),
+			Cores:   make([]MessageElementCore_13, 0, number),
+		}
+		msgBody = msgBody[IOASize:]
+		for i := 0; i < number*M_ME_NC_1_SQ_1_MSG_LEN; i += M_ME_NC_1_SQ_1_MSG_LEN {
+			elements.Cores = append(elements.Cores, parseCore_13(msgBody[i:]))
 		}
-		return elements, nil
+		return elements
+	}
+}
+
+func parseCore_13(b []byte) MessageElementCore_13 {
+	return MessageElementCore_13{
+		Value: math.Float32frombits(binary.LittleEndian.Uint32(b[0:4])),
+		QDS:   ParseQDS(b[4]),
 	}
 }
 
 // ParseQDS 解析QDS
 func ParseQDS(qds byte) QDS {
 	return QDS{
-		OV: qds&0x01 == 1,
-		BL: qds&0x10 == 1,
-		SB: qds&0x20 == 1,
-		NT: qds&0x40 == 1,
-		IV: qds&0x80 == 1,
+		OV: qds&0x01 != 0,
+		BL: qds&0x10 != 0,
+		SB: qds&0x20 != 0,
+		NT: qds&0x40 != 0,
+		IV: qds&0x80 != 0,
 	}
 }
+
+// String 置位的品质标志，如 IV|NT，均未置位时为-
+func (qds QDS) String() string {
+	return flagString([]string{"OV", "BL", "SB", "NT", "IV"}, qds.OV, qds.BL, qds.SB, qds.NT, qds.IV)
+}
@@ -0,0 +1,214 @@
+package elements
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+const (
+	M_ME_ND_1_SQ_1_MSG_LEN = 2
+	M_ME_ND_1_SQ_0_MSG_LEN = 5
+)
+
+// MessageElement_21_SQ_1 测量值，不带品质描述词的规一化值，《DLT 634.5101-2002》 7.3.1.21 21:M_ME_ND_1，SQ=1的信息元素
+type MessageElement_21_SQ_1 struct {
+	Address uint32
+	Cores   []MessageElementCore_21
+}
+
+func (e MessageElement_21_SQ_1) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_21_SQ_1) AppendTo(dst []byte) []byte {
+	dst = appendIOA(dst, e.Address)
+	for _, c := range e.Cores {
+		dst = c.AppendTo(dst)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_21_SQ_1) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_21_SQ_1) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_21_SQ_1) Size() int {
+	return IOASize + len(e.Cores)*M_ME_ND_1_SQ_1_MSG_LEN
+}
+
+// Objects 按序号展开地址后的信息对象
+func (e MessageElement_21_SQ_1) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e.Cores))
+	for i, c := range e.Cores {
+		objs[i] = MessageElement_21_SQ_0_Ele{Address: e.Address + uint32(i), Core: c}
+	}
+	return objs
+}
+
+// MessageElement_21_SQ_0_Ele 测量值，不带品质描述词的规一化值，《DLT 634.5101-2002》 7.3.1.21 21:M_ME_ND_1，SQ=0的信息元素
+type MessageElement_21_SQ_0_Ele struct {
+	Address uint32
+	Core    MessageElementCore_21
+}
+
+func (e MessageElement_21_SQ_0_Ele) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_21_SQ_0_Ele) AppendTo(dst []byte) []byte {
+	return e.Core.AppendTo(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_21_SQ_0_Ele) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] Value=0.5
+func (e MessageElement_21_SQ_0_Ele) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_21_SQ_0_Ele) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为M_ME_ND_1
+func (e *MessageElement_21_SQ_0_Ele) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, M_ME_ND_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_21_SQ_0_Ele) Size() int {
+	return M_ME_ND_1_SQ_0_MSG_LEN
+}
+
+// TypeID 类型标识
+func (e MessageElement_21_SQ_0_Ele) TypeID() byte {
+	return M_ME_ND_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_21_SQ_0_Ele) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_21_SQ_0_Ele) AppendElement(dst []byte) []byte {
+	return e.Core.AppendTo(dst)
+}
+
+// Float 信息对象的值
+func (e MessageElement_21_SQ_0_Ele) Float() float64 {
+	return e.Core.Value.Float()
+}
+
+// Quality 品质描述词，该类型不带品质描述词，恒为零值
+func (e MessageElement_21_SQ_0_Ele) Quality() QDS {
+	return QDS{}
+}
+
+type MessageElement_21_SQ_0 []MessageElement_21_SQ_0_Ele
+
+func (e MessageElement_21_SQ_0) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_21_SQ_0) AppendTo(dst []byte) []byte {
+	for _, ele := range e {
+		dst = ele.AppendTo(dst)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_21_SQ_0) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_21_SQ_0) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_21_SQ_0) Size() int {
+	return len(e) * M_ME_ND_1_SQ_0_MSG_LEN
+}
+
+// Objects 信息对象
+func (e MessageElement_21_SQ_0) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e))
+	for i, ele := range e {
+		objs[i] = ele
+	}
+	return objs
+}
+
+// MessageElementCore_21 不带品质描述词的规一化值
+type MessageElementCore_21 struct {
+	Value NVA // 规一化值
+}
+
+func (c MessageElementCore_21) ConvertBytes() []byte {
+	return c.AppendTo(make([]byte, 0, M_ME_ND_1_SQ_1_MSG_LEN))
+}
+
+// AppendTo 将编码结果追加到dst
+func (c MessageElementCore_21) AppendTo(dst []byte) []byte {
+	return append(dst, byte(c.Value), byte(c.Value>>8))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (c MessageElementCore_21) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, c)
+}
+
+// String 如 Value=0.5
+func (c MessageElementCore_21) String() string {
+	return objectString(c)
+}
+
+func parseM_ME_ND_1(msgBody []byte, dui DUI) BytesConverter {
+	vsq := dui.VSQ()
+	number := vsq.Number()
+
+	switch {
+	case !vsq.SQ():
+		elements := make(MessageElement_21_SQ_0, 0, number)
+		for i := 0; i < number*M_ME_ND_1_SQ_0_MSG_LEN; i += M_ME_ND_1_SQ_0_MSG_LEN {
+			elements = append(elements, MessageElement_21_SQ_0_Ele{
+				Address: parseIOA(msgBody[i:]),
+				Core:    parseCore_21(msgBody[i+IOASize:]),
+			})
+		}
+		return elements
+	default:
+		elements := MessageElement_21_SQ_1{
+			Address: parseIOA(msgBody),
+			Cores:   make([]MessageElementCore_21, 0, number),
+		}
+		msgBody = msgBody[IOASize:]
+		for i := 0; i < number*M_ME_ND_1_SQ_1_MSG_LEN; i += M_ME_ND_1_SQ_1_MSG_LEN {
+			elements.Cores = append(elements.Cores, parseCore_21(msgBody[i:]))
+		}
+		return elements
+	}
+}
+
+func parseCore_21(b []byte) MessageElementCore_21 {
+	return MessageElementCore_21{
+		Value: NVA(binary.LittleEndian.Uint16(b[0:2])),
+	}
+}
@@ -0,0 +1,256 @@
+package elements
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	M_PS_NA_1_SQ_1_MSG_LEN = 5
+	M_PS_NA_1_SQ_0_MSG_LEN = 8
+)
+
+// MessageElement_20_SQ_1 带变位检出的成组单点信息，《DLT 634.5101-2002》 7.3.1.20 20:M_PS_NA_1，SQ=1的信息元素
+type MessageElement_20_SQ_1 struct {
+	Address uint32
+	Cores   []MessageElementCore_20
+}
+
+func (e MessageElement_20_SQ_1) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_20_SQ_1) AppendTo(dst []byte) []byte {
+	dst = appendIOA(dst, e.Address)
+	for _, c := range e.Cores {
+		dst = c.AppendTo(dst)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_20_SQ_1) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_20_SQ_1) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_20_SQ_1) Size() int {
+	return IOASize + len(e.Cores)*M_PS_NA_1_SQ_1_MSG_LEN
+}
+
+// Objects 按序号展开地址后的信息对象
+func (e MessageElement_20_SQ_1) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e.Cores))
+	for i, c := range e.Cores {
+		objs[i] = MessageElement_20_SQ_0_Ele{Address: e.Address + uint32(i), Core: c}
+	}
+	return objs
+}
+
+// MessageElement_20_SQ_0_Ele 带变位检出的成组单点信息，《DLT 634.5101-2002》 7.3.1.20 20:M_PS_NA_1，SQ=0的信息元素
+type MessageElement_20_SQ_0_Ele struct {
+	Address uint32
+	Core    MessageElementCore_20
+}
+
+func (e MessageElement_20_SQ_0_Ele) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_20_SQ_0_Ele) AppendTo(dst []byte) []byte {
+	return e.Core.AppendTo(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_20_SQ_0_Ele) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] SCD=ST=0000000000000001 CD=0000000000000010 QDS=-
+func (e MessageElement_20_SQ_0_Ele) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_20_SQ_0_Ele) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为M_PS_NA_1
+func (e *MessageElement_20_SQ_0_Ele) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, M_PS_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_20_SQ_0_Ele) Size() int {
+	return M_PS_NA_1_SQ_0_MSG_LEN
+}
+
+// TypeID 类型标识
+func (e MessageElement_20_SQ_0_Ele) TypeID() byte {
+	return M_PS_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_20_SQ_0_Ele) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_20_SQ_0_Ele) AppendElement(dst []byte) []byte {
+	return e.Core.AppendTo(dst)
+}
+
+type MessageElement_20_SQ_0 []MessageElement_20_SQ_0_Ele
+
+func (e MessageElement_20_SQ_0) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_20_SQ_0) AppendTo(dst []byte) []byte {
+	for _, ele := range e {
+		dst = ele.AppendTo(dst)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_20_SQ_0) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 各信息对象，以分号分隔
+func (e MessageElement_20_SQ_0) String() string {
+	return objectsString(e.Objects())
+}
+
+// Size 编码长度
+func (e MessageElement_20_SQ_0) Size() int {
+	return len(e) * M_PS_NA_1_SQ_0_MSG_LEN
+}
+
+// Objects 信息对象
+func (e MessageElement_20_SQ_0) Objects() []InformationObject {
+	objs := make([]InformationObject, len(e))
+	for i, ele := range e {
+		objs[i] = ele
+	}
+	return objs
+}
+
+// MessageElementCore_20 状态和状态变位检出及品质描述词
+type MessageElementCore_20 struct {
+	SCD SCD
+	QDS QDS
+}
+
+func (c MessageElementCore_20) ConvertBytes() []byte {
+	return c.AppendTo(make([]byte, 0, M_PS_NA_1_SQ_1_MSG_LEN))
+}
+
+// AppendTo 将编码结果追加到dst
+func (c MessageElementCore_20) AppendTo(dst []byte) []byte {
+	return c.QDS.AppendTo(c.SCD.AppendTo(dst))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (c MessageElementCore_20) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, c)
+}
+
+// String 如 SCD=ST=0000000000000001 CD=0000000000000010 QDS=-
+func (c MessageElementCore_20) String() string {
+	return objectString(c)
+}
+
+func parseM_PS_NA_1(msgBody []byte, dui DUI) BytesConverter {
+	vsq := dui.VSQ()
+	number := vsq.Number()
+
+	switch {
+	case !vsq.SQ():
+		elements := make(MessageElement_20_SQ_0, 0, number)
+		for i := 0; i < number*M_PS_NA_1_SQ_0_MSG_LEN; i += M_PS_NA_1_SQ_0_MSG_LEN {
+			elements = append(elements, MessageElement_20_SQ_0_Ele{
+				Address: parseIOA(msgBody[i:]),
+				Core:    parseCore_20(msgBody[i+IOASize:]),
+			})
+		}
+		return elements
+	default:
+		elements := MessageElement_20_SQ_1{
+			Address: parseIOA(msgBody),
+			Cores:   make([]MessageElementCore_20, 0, number),
+		}
+		msgBody = msgBody[IOASize:]
+		for i := 0; i < number*M_PS_NA_1_SQ_1_MSG_LEN; i += M_PS_NA_1_SQ_1_MSG_LEN {
+			elements.Cores = append(elements.Cores, parseCore_20(msgBody[i:]))
+		}
+		return elements
+	}
+}
+
+func parseCore_20(b []byte) MessageElementCore_20 {
+	return MessageElementCore_20{
+		SCD: ParseSCD(b),
+		QDS: ParseQDS(b[4]),
+	}
+}
+
+// SCD 状态和状态变位检出，《DLT 634.5101-2002》 7.2.6.40
+type SCD struct {
+	ST uint16 // 16个单点状态，第i位为第i个遥信
+	CD uint16 // 16个状态变位检出，第i位置位表示第i个遥信在上次报告后至少变位一次
+}
+
+// PackedPoint 成组单点信息中的单个遥信
+type PackedPoint struct {
+	Index   int  // 序号 0-15
+	Value   bool // 当前状态
+	Changed bool // 上次报告后是否变位，即使当前状态与上次报告相同
+}
+
+// Points 展开为16个遥信
+func (s SCD) Points() [16]PackedPoint {
+	var points [16]PackedPoint
+	for i := range points {
+		points[i] = PackedPoint{
+			Index:   i,
+			Value:   s.ST&(1<<i) != 0,
+			Changed: s.CD&(1<<i) != 0,
+		}
+	}
+	return points
+}
+
+// AppendTo 将编码结果追加到dst
+func (s SCD) AppendTo(dst []byte) []byte {
+	return append(dst, byte(s.ST), byte(s.ST>>8), byte(s.CD), byte(s.CD>>8))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (s SCD) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, s)
+}
+
+// ParseSCD 解析SCD
+func ParseSCD(b []byte) SCD {
+	return SCD{
+		ST: binary.LittleEndian.Uint16(b[0:2]),
+		CD: binary.LittleEndian.Uint16(b[2:4]),
+	}
+}
+
+// String 状态及变位检出，二进制，第1个遥信在最右
+func (s SCD) String() string {
+	return fmt.Sprintf("ST=%016b CD=%016b", s.ST, s.CD)
+}
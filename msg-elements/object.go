@@ -0,0 +1,116 @@
+package elements
+
+import "io"
+
+// InformationObject 带信息对象地址的单个信息对象
+//
+// 各类型SQ=0的信息元素（如 MessageElement_13_SQ_0_Ele）及命令信息元素实现了该接口。
+type InformationObject interface {
+	TypeID() byte                    // 类型标识
+	IOA() uint32                     // 信息对象地址
+	AppendElement(dst []byte) []byte // 将信息元素（不含信息对象地址）追加到dst
+}
+
+// Measurand 可转换为数值的信息对象，如测量值、累计量
+type Measurand interface {
+	InformationObject
+	Float() float64 // 信息对象的值
+	Quality() QDS   // 品质描述词
+}
+
+// objectLister 可展开为信息对象的信息体
+type objectLister interface {
+	Objects() []InformationObject
+}
+
+// Objects 展开信息体中的信息对象，SQ=1时各信息对象的地址已按序号计算
+//
+// 信息体为空或不支持展开时返回nil。
+func (asdu ASDU) Objects() []InformationObject {
+	l, ok := asdu.MessageBody.(objectLister)
+	if !ok {
+		return nil
+	}
+	return l.Objects()
+}
+
+// ObjectList SQ=0的通用信息体，每个信息对象带有各自的地址
+type ObjectList []InformationObject
+
+func (l ObjectList) ConvertBytes() []byte {
+	return l.AppendTo(make([]byte, 0, l.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (l ObjectList) AppendTo(dst []byte) []byte {
+	for _, o := range l {
+		dst = o.AppendElement(appendIOA(dst, o.IOA()))
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (l ObjectList) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, l)
+}
+
+// Objects 信息对象
+func (l ObjectList) Objects() []InformationObject {
+	return l
+}
+
+// Size 编码长度
+func (l ObjectList) Size() int {
+	size := 0
+	for _, o := range l {
+		size += IOASize + elementSizeOf(o)
+	}
+	return size
+}
+
+// ObjectSequence SQ=1的通用信息体，信息对象地址自Address起连续递增
+type ObjectSequence struct {
+	Address  uint32
+	Elements []InformationObject // 各信息对象的地址须自Address起连续，编码时不使用
+}
+
+func (s ObjectSequence) ConvertBytes() []byte {
+	return s.AppendTo(make([]byte, 0, s.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (s ObjectSequence) AppendTo(dst []byte) []byte {
+	dst = appendIOA(dst, s.Address)
+	for _, o := range s.Elements {
+		dst = o.AppendElement(dst)
+	}
+	return dst
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (s ObjectSequence) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, s)
+}
+
+// Size 编码长度
+func (s ObjectSequence) Size() int {
+	size := IOASize
+	for _, o := range s.Elements {
+		size += elementSizeOf(o)
+	}
+	return size
+}
+
+// Objects 信息对象
+func (s ObjectSequence) Objects() []InformationObject {
+	return s.Elements
+}
+
+// elementSizeOf 信息对象的信息元素长度（不含信息对象地址），段的长度由段数据确定
+func elementSizeOf(o InformationObject) int {
+	if seg, ok := o.(MessageElement_125); ok {
+		return seg.Size() - IOASize
+	}
+	n, _ := elementSize(o.TypeID())
+	return n
+}
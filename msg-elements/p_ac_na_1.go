@@ -0,0 +1,100 @@
+package elements
+
+import "io"
+
+// QPA:
+// 	1 激活/停止激活之前装载的参数（信息对象地址为0）
+// 	2 激活/停止激活所寻址信息对象的参数
+// 	3 激活/停止激活所寻址的持续循环或周期传输的信息对象
+
+const (
+	QPA_GENERAL = 1
+	QPA_OBJECT  = 2
+	QPA_CYCLIC  = 3
+)
+
+// MessageElement_113 参数激活，《DLT 634.5101-2002》 7.3.5.4 113:P_AC_NA_1
+type MessageElement_113 struct {
+	Address uint32 // 信息对象地址，QPA_GENERAL时为0
+	QPA     byte   // 参数激活限定词，《DLT 634.5101-2002》 7.2.6.25
+}
+
+func (e MessageElement_113) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_113) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_113) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] QPA=3
+func (e MessageElement_113) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_113) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为P_AC_NA_1
+func (e *MessageElement_113) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, P_AC_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_113) Size() int {
+	return IOASize + 1
+}
+
+// TypeID 类型标识
+func (e MessageElement_113) TypeID() byte {
+	return P_AC_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_113) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_113) AppendElement(dst []byte) []byte {
+	return append(dst, e.QPA)
+}
+
+// Objects 信息对象
+func (e MessageElement_113) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseP_AC_NA_1(msgBody []byte) MessageElement_113 {
+	return MessageElement_113{
+		Address: parseIOA(msgBody),
+		QPA:     msgBody[IOASize],
+	}
+}
+
+// NewASDUP_AC_NA_1 创建参数激活命令，激活时cause为COT_ACT，停止激活时为COT_DEACT
+func NewASDUP_AC_NA_1(cause Cause, publicAddress uint16, address uint32, qpa byte) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         P_AC_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_113{
+			Address: address,
+			QPA:     qpa,
+		},
+	}
+}
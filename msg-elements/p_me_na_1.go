@@ -0,0 +1,173 @@
+package elements
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	QPM_THRESHOLD  = 1 // 门限值
+	QPM_SMOOTHING  = 2 // 平滑系数（滤波时间常数）
+	QPM_LOW_LIMIT  = 3 // 传送测量值的下限
+	QPM_HIGH_LIMIT = 4 // 传送测量值的上限
+)
+
+// QPM 测量值参数限定词，《DLT 634.5101-2002》 7.2.6.24
+type QPM struct {
+	KPA byte // 参数类别 0-63，QPM_THRESHOLD等
+	LPC bool // false(0) = 当地参数未改变 | true(1) = 当地参数改变
+	POP bool // false(0) = 参数运行 | true(1) = 参数未运行
+}
+
+// AppendTo 将编码结果追加到dst
+func (q QPM) AppendTo(dst []byte) []byte {
+	b := q.KPA & 0x3F
+	if q.LPC {
+		b |= 0x40
+	}
+	if q.POP {
+		b |= 0x80
+	}
+	return append(dst, b)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (q QPM) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, q)
+}
+
+// ParseQPM 解析QPM
+func ParseQPM(b byte) QPM {
+	return QPM{
+		KPA: b & 0x3F,
+		LPC: b&0x40 != 0,
+		POP: b&0x80 != 0,
+	}
+}
+
+// String 如 门限值(1) LPC|POP
+func (q QPM) String() string {
+	var s string
+	switch q.KPA {
+	case QPM_THRESHOLD:
+		s = "门限值(1)"
+	case QPM_SMOOTHING:
+		s = "平滑系数(2)"
+	case QPM_LOW_LIMIT:
+		s = "下限(3)"
+	case QPM_HIGH_LIMIT:
+		s = "上限(4)"
+	default:
+		s = fmt.Sprintf("未定义(%d)", q.KPA)
+	}
+	return s + " " + flagString([]string{"LPC", "POP"}, q.LPC, q.POP)
+}
+
+// Parameter 测量值参数（P_ME_NA_1、P_ME_NB_1、P_ME_NC_1）
+type Parameter interface {
+	InformationObject
+	BytesConverter
+	Float() float64 // 参数值，规一化值为对应的小数
+	Qualifier() QPM // 测量值参数限定词
+}
+
+// MessageElement_110 测量值参数，规一化值，《DLT 634.5101-2002》 7.3.5.1 110:P_ME_NA_1
+type MessageElement_110 struct {
+	Address uint32 // 信息对象地址
+	Value   NVA    // 规一化值
+	QPM     QPM    // 测量值参数限定词
+}
+
+func (e MessageElement_110) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_110) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_110) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] Value=0.5 QPM=门限值(1) -
+func (e MessageElement_110) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_110) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为P_ME_NA_1
+func (e *MessageElement_110) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, P_ME_NA_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_110) Size() int {
+	return IOASize + 3
+}
+
+// TypeID 类型标识
+func (e MessageElement_110) TypeID() byte {
+	return P_ME_NA_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_110) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_110) AppendElement(dst []byte) []byte {
+	dst = append(dst, byte(e.Value), byte(e.Value>>8))
+	return e.QPM.AppendTo(dst)
+}
+
+// Float 参数值，规一化值对应的小数
+func (e MessageElement_110) Float() float64 {
+	return e.Value.Float()
+}
+
+// Qualifier 测量值参数限定词
+func (e MessageElement_110) Qualifier() QPM {
+	return e.QPM
+}
+
+// Objects 信息对象
+func (e MessageElement_110) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseP_ME_NA_1(msgBody []byte) MessageElement_110 {
+	return MessageElement_110{
+		Address: parseIOA(msgBody),
+		Value:   NVA(binary.LittleEndian.Uint16(msgBody[IOASize:])),
+		QPM:     ParseQPM(msgBody[IOASize+2]),
+	}
+}
+
+// NewASDUP_ME_NA_1 创建测量值参数（规一化值）
+func NewASDUP_ME_NA_1(cause Cause, publicAddress uint16, address uint32, value NVA, qpm QPM) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         P_ME_NA_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_110{
+			Address: address,
+			Value:   value,
+			QPM:     qpm,
+		},
+	}
+}
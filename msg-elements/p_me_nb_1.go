@@ -0,0 +1,106 @@
+package elements
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// MessageElement_111 测量值参数，标度化值，《DLT 634.5101-2002》 7.3.5.2 111:P_ME_NB_1
+type MessageElement_111 struct {
+	Address uint32 // 信息对象地址
+	Value   int16  // 标度化值
+	QPM     QPM    // 测量值参数限定词
+}
+
+func (e MessageElement_111) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_111) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_111) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] Value=100 QPM=门限值(1) -
+func (e MessageElement_111) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_111) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为P_ME_NB_1
+func (e *MessageElement_111) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, P_ME_NB_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_111) Size() int {
+	return IOASize + 3
+}
+
+// TypeID 类型标识
+func (e MessageElement_111) TypeID() byte {
+	return P_ME_NB_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_111) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_111) AppendElement(dst []byte) []byte {
+	dst = append(dst, byte(e.Value), byte(e.Value>>8))
+	return e.QPM.AppendTo(dst)
+}
+
+// Float 参数值，标度化值
+func (e MessageElement_111) Float() float64 {
+	return float64(e.Value)
+}
+
+// Qualifier 测量值参数限定词
+func (e MessageElement_111) Qualifier() QPM {
+	return e.QPM
+}
+
+// Objects 信息对象
+func (e MessageElement_111) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseP_ME_NB_1(msgBody []byte) MessageElement_111 {
+	return MessageElement_111{
+		Address: parseIOA(msgBody),
+		Value:   int16(binary.LittleEndian.Uint16(msgBody[IOASize:])),
+		QPM:     ParseQPM(msgBody[IOASize+2]),
+	}
+}
+
+// NewASDUP_ME_NB_1 创建测量值参数（标度化值）
+func NewASDUP_ME_NB_1(cause Cause, publicAddress uint16, address uint32, value int16, qpm QPM) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         P_ME_NB_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_111{
+			Address: address,
+			Value:   value,
+			QPM:     qpm,
+		},
+	}
+}
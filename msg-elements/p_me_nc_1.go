@@ -0,0 +1,108 @@
+package elements
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// MessageElement_112 测量值参数，短浮点数，《DLT 634.5101-2002》 7.3.5.3 112:P_ME_NC_1
+type MessageElement_112 struct {
+	Address uint32  // 信息对象地址
+	Value   float32 // 短浮点数
+	QPM     QPM     // 测量值参数限定词
+}
+
+func (e MessageElement_112) ConvertBytes() []byte {
+	return e.AppendTo(make([]byte, 0, e.Size()))
+}
+
+// AppendTo 将编码结果追加到dst
+func (e MessageElement_112) AppendTo(dst []byte) []byte {
+	return e.AppendElement(appendIOA(dst, e.Address))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (e MessageElement_112) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, e)
+}
+
+// String 如 IOA[1] Value=1.5 QPM=门限值(1) POP
+func (e MessageElement_112) String() string {
+	return objectString(e)
+}
+
+// MarshalJSON 编码为带类型标识助记符的JSON，见 MarshalObject
+func (e MessageElement_112) MarshalJSON() ([]byte, error) {
+	return MarshalObject(e)
+}
+
+// UnmarshalJSON 由JSON还原信息对象，type须为P_ME_NC_1
+func (e *MessageElement_112) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, P_ME_NC_1, e)
+}
+
+// Size 编码长度
+func (e MessageElement_112) Size() int {
+	return IOASize + 5
+}
+
+// TypeID 类型标识
+func (e MessageElement_112) TypeID() byte {
+	return P_ME_NC_1
+}
+
+// IOA 信息对象地址
+func (e MessageElement_112) IOA() uint32 {
+	return e.Address
+}
+
+// AppendElement 将信息元素追加到dst
+func (e MessageElement_112) AppendElement(dst []byte) []byte {
+	v := math.Float32bits(e.Value)
+	dst = append(dst, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+	return e.QPM.AppendTo(dst)
+}
+
+// Float 参数值，短浮点数
+func (e MessageElement_112) Float() float64 {
+	return float64(e.Value)
+}
+
+// Qualifier 测量值参数限定词
+func (e MessageElement_112) Qualifier() QPM {
+	return e.QPM
+}
+
+// Objects 信息对象
+func (e MessageElement_112) Objects() []InformationObject {
+	return []InformationObject{e}
+}
+
+func parseP_ME_NC_1(msgBody []byte) MessageElement_112 {
+	return MessageElement_112{
+		Address: parseIOA(msgBody),
+		Value:   math.Float32frombits(binary.LittleEndian.Uint32(msgBody[IOASize:])),
+		QPM:     ParseQPM(msgBody[IOASize+4]),
+	}
+}
+
+// NewASDUP_ME_NC_1 创建测量值参数（短浮点数）
+func NewASDUP_ME_NC_1(cause Cause, publicAddress uint16, address uint32, value float32, qpm QPM) ASDU {
+	return ASDU{
+		DUI: DUI{
+			TypeIdentification:         P_ME_NC_1,
+			VariableStructureQualifier: 0x01,
+			COT:                        COT{Cause: cause},
+			CauseExtEnable:             true,
+			PublicAddressLow:           byte(publicAddress),
+			PublicAddressHig:           byte(publicAddress >> 8),
+			PublicAddressHigEnable:     true,
+		},
+		MessageBody: MessageElement_112{
+			Address: address,
+			Value:   value,
+			QPM:     qpm,
+		},
+	}
+}
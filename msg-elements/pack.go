@@ -0,0 +1,109 @@
+package elements
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Pack 将同一类型的信息对象打包为尽量少的ASDU
+//
+// dui提供类型标识、传送原因及公共地址，可变结构限定词由Pack设置，传送原因及公共地址长度按p设置。
+// 信息对象按地址排序后打包为数量最少的ASDU：地址连续的信息对象可使用SQ=1，其余使用SQ=0，
+// ASDU数量相同时尽量使用SQ=1以减少字节数；每个ASDU不超过 MaxObjects 个信息对象及 MaxASDULen 字节。
+// 命令类型每个ASDU只包含一个信息对象。
+func Pack(dui DUI, objs []InformationObject, p Params) ([]ASDU, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	size, ok := elementSize(dui.TypeIdentification)
+	if !ok {
+		return nil, &UnknownTypeError{TypeID: dui.TypeIdentification}
+	}
+	for _, o := range objs {
+		if o.TypeID() != dui.TypeIdentification {
+			return nil, fmt.Errorf("信息对象[%d]的类型标识[%d]与ASDU类型标识[%d]不符", o.IOA(), o.TypeID(), dui.TypeIdentification)
+		}
+		if o.IOA() > 0xFFFFFF {
+			return nil, fmt.Errorf("信息对象地址[%d]超过3字节", o.IOA())
+		}
+	}
+
+	dui = p.Apply(dui)
+	var result []ASDU
+	emit := func(sq bool, number int, body BytesConverter) {
+		d := dui
+		d.VariableStructureQualifier = byte(NewVSQ(sq, number))
+		result = append(result, ASDU{DUI: d, MessageBody: body})
+	}
+	if singleObject(dui.TypeIdentification) {
+		for _, o := range objs {
+			emit(false, 1, ObjectList{o})
+		}
+		return result, nil
+	}
+
+	sorted := make([]InformationObject, len(objs))
+	copy(sorted, objs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].IOA() < sorted[j].IOA()
+	})
+
+	room := MaxASDULen - dui.Size()
+	maxList := min(MaxObjects, room/(IOASize+size))
+	maxSeq := min(MaxObjects, (room-IOASize)/size)
+
+	// 地址连续的信息对象按maxSeq分段，每段可单独作为一个SQ=1的ASDU
+	type segment struct{ run, n int }
+	var runs []int
+	var segments []segment
+	for i := 0; i < len(sorted); {
+		run := 1
+		for i+run < len(sorted) && sorted[i+run].IOA() == sorted[i].IOA()+uint32(run) {
+			run++
+		}
+		for n := run; n >= 2 && maxSeq >= 2; n -= maxSeq {
+			segments = append(segments, segment{run: len(runs), n: min(n, maxSeq)})
+		}
+		runs = append(runs, run)
+		i += run
+	}
+	// 使用SQ=1的段越长，SQ=0需要的ASDU越少，因此按段长从大到小依次尝试
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].n > segments[j].n
+	})
+	best, bestCount := 0, (len(sorted)+maxList-1)/maxList
+	rest := len(sorted)
+	for t, seg := range segments {
+		rest -= seg.n
+		if count := t + 1 + (rest+maxList-1)/maxList; count <= bestCount {
+			best, bestCount = t+1, count
+		}
+	}
+	seqs := make([]int, len(runs))
+	for _, seg := range segments[:best] {
+		seqs[seg.run]++
+	}
+
+	var list ObjectList
+	i := 0
+	for r, run := range runs {
+		objs := sorted[i : i+run : i+run]
+		i += run
+		for k := 0; k < seqs[r]; k++ {
+			n := min(len(objs), maxSeq)
+			emit(true, n, ObjectSequence{Address: objs[0].IOA(), Elements: objs[:n:n]})
+			objs = objs[n:]
+		}
+		for _, o := range objs {
+			list = append(list, o)
+			if len(list) == maxList {
+				emit(false, len(list), list)
+				list = nil
+			}
+		}
+	}
+	if len(list) > 0 {
+		emit(false, len(list), list)
+	}
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package elements
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_Pack(t *testing.T) {
+	var objs []InformationObject
+	// 1000个连续地址及300个离散地址
+	for i := 0; i < 1000; i++ {
+		objs = append(objs, MessageElement_13_SQ_0_Ele{Address: uint32(0x4001 + i), Core: MessageElementCore_13{Value: float32(i)}})
+	}
+	for i := 0; i < 300; i++ {
+		objs = append(objs, MessageElement_13_SQ_0_Ele{Address: uint32(0x8001 + 2*i), Core: MessageElementCore_13{Value: float32(-i)}})
+	}
+
+	for _, p := range []Params{DefaultParams, {CauseSize: 1, CommonAddrSize: 1}} {
+		asdus, err := Pack(DUI{TypeIdentification: M_ME_NC_1, COT: COT{Cause: COT_INTRGEN}, PublicAddressLow: 1}, objs, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := make(map[uint32]string)
+		for _, o := range objs {
+			want[o.IOA()] = fmt.Sprintf("%X", o.AppendElement(nil))
+		}
+		d := NewDecoder(p)
+		seq := 0
+		for _, asdu := range asdus {
+			input := asdu.ConvertBytes()
+			if len(input) > MaxASDULen {
+				t.Fatalf("ASDU长度[%d]超过%d", len(input), MaxASDULen)
+			}
+			if err := d.Reset(input); err != nil {
+				t.Fatal(err)
+			}
+			if asdu.DUI.VariableStructureQualifier>>7 == 1 {
+				seq++
+			}
+			for d.Next() {
+				obj := d.Object()
+				if want[obj.Address] != fmt.Sprintf("%X", obj.Raw) {
+					t.Fatalf("信息对象[%X]的信息元素[%X]应为[%s]", obj.Address, obj.Raw, want[obj.Address])
+				}
+				delete(want, obj.Address)
+			}
+		}
+		if len(want) != 0 {
+			t.Fatalf("%d个信息对象未被打包", len(want))
+		}
+		// 连续部分每帧最多48个，离散部分每帧最多30个
+		if p == DefaultParams && (seq != 21 || len(asdus) != 31) {
+			t.Fatalf("打包结果SQ=1共%d帧、总共%d帧，应为21帧、31帧", seq, len(asdus))
+		}
+	}
+
+	// 3个连续地址及27个离散地址可放入一个SQ=0的ASDU
+	objs = objs[:0:0]
+	for i := 0; i < 3; i++ {
+		objs = append(objs, MessageElement_13_SQ_0_Ele{Address: uint32(0x4001 + i)})
+	}
+	for i := 0; i < 27; i++ {
+		objs = append(objs, MessageElement_13_SQ_0_Ele{Address: uint32(0x8001 + 2*i)})
+	}
+	asdus, err := Pack(DUI{TypeIdentification: M_ME_NC_1, COT: COT{Cause: COT_SPONT}}, objs, DefaultParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(asdus) != 1 || asdus[0].DUI.VariableStructureQualifier != 30 {
+		t.Fatalf("打包结果[%v]应为一帧SQ=0", asdus)
+	}
+	// 再增加一个离散地址后，连续地址使用SQ=1才能保持两帧
+	objs = append(objs, MessageElement_13_SQ_0_Ele{Address: 0x9001})
+	if asdus, err = Pack(DUI{TypeIdentification: M_ME_NC_1, COT: COT{Cause: COT_SPONT}}, objs, DefaultParams); err != nil {
+		t.Fatal(err)
+	}
+	if len(asdus) != 2 || asdus[0].DUI.VariableStructureQualifier != 0x83 {
+		t.Fatalf("打包结果[%v]应为一帧SQ=1及一帧SQ=0", asdus)
+	}
+
+	cmd := []InformationObject{MessageElement_100{QOI: QOI_GLOBAL_CALL}, MessageElement_100{QOI: QIO_GROUP_1}}
+	asdus, err = Pack(DUI{TypeIdentification: C_IC_NA_1, COT: COT{Cause: COT_ACT}}, cmd, DefaultParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(asdus) != 2 || asdus[0].DUI.VariableStructureQualifier != 1 {
+		t.Fatalf("命令应每帧一个信息对象: %v", asdus)
+	}
+
+	if _, err := Pack(DUI{TypeIdentification: M_ME_NA_1}, objs, DefaultParams); err == nil {
+		t.Fatal("类型标识不符时应返回异常")
+	}
+}
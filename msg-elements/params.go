@@ -0,0 +1,150 @@
+package elements
+
+import (
+	"fmt"
+)
+
+const (
+	// IOASize 信息对象地址长度，104规约固定为3字节
+	IOASize = 3
+	// MaxASDULen ASDU最大长度（APDU最大长度253减去控制域4字节）
+	MaxASDULen = 249
+	// MaxObjects 可变结构限定词可表示的最大信息对象数目
+	MaxObjects = 127
+)
+
+// Params ASDU编解码参数
+type Params struct {
+	CauseSize      int  // 传送原因长度，1或2，0表示默认值2
+	CommonAddrSize int  // 公共地址长度，1或2，0表示默认值2
+	Lenient        bool // 宽松模式：容忍长度域、信息对象数目与实际报文长度不符，仅解析完整的信息对象
+}
+
+// DefaultParams 104规约标准参数：传送原因2字节，公共地址2字节
+var DefaultParams = Params{
+	CauseSize:      2,
+	CommonAddrSize: 2,
+}
+
+func (p Params) normalize() Params {
+	if p.CauseSize == 0 {
+		p.CauseSize = 2
+	}
+	if p.CommonAddrSize == 0 {
+		p.CommonAddrSize = 2
+	}
+	return p
+}
+
+// Validate 检查参数是否合法
+func (p Params) Validate() error {
+	p = p.normalize()
+	if p.CauseSize != 1 && p.CauseSize != 2 {
+		return fmt.Errorf("传送原因长度[%d]非法", p.CauseSize)
+	}
+	if p.CommonAddrSize != 1 && p.CommonAddrSize != 2 {
+		return fmt.Errorf("公共地址长度[%d]非法", p.CommonAddrSize)
+	}
+	return nil
+}
+
+// DUISize 数据单元标识符长度
+func (p Params) DUISize() int {
+	p = p.normalize()
+	return 2 + p.CauseSize + p.CommonAddrSize
+}
+
+// Apply 按参数设置dui的传送原因及公共地址长度
+func (p Params) Apply(dui DUI) DUI {
+	p = p.normalize()
+	dui.CauseExtEnable = p.CauseSize == 2
+	dui.PublicAddressHigEnable = p.CommonAddrSize == 2
+	return dui
+}
+
+// singleObject 类型是否只能包含一个信息对象（如命令）
+func singleObject(t byte) bool {
+	switch t {
+	case C_SC_NA_1, C_DC_NA_1, C_RC_NA_1, C_SE_NA_1, C_SE_NB_1, C_SE_NC_1, C_BO_NA_1,
+		C_SC_TA_1, C_DC_TA_1, C_RC_TA_1, C_SE_TA_1, C_SE_TB_1, C_SE_TC_1, C_BO_TA_1,
+		M_EI_NA_1, C_IC_NA_1, C_CI_NA_1, C_CS_NA_1, C_TS_NA_1, C_RP_NA_1, C_TS_TA_1,
+		P_ME_NA_1, P_ME_NB_1, P_ME_NC_1, P_AC_NA_1,
+		F_FR_NA_1, F_SR_NA_1, F_SC_NA_1, F_LS_NA_1, F_AF_NA_1, F_SG_NA_1:
+		return true
+	default:
+		return false
+	}
+}
+
+// elementSize 各类型信息元素（不含信息对象地址）的长度
+func elementSize(t byte) (int, bool) {
+	switch t {
+	case M_BO_NA_1:
+		return M_BO_NA_1_SQ_1_MSG_LEN, true
+	case M_ME_NA_1:
+		return 3, true
+	case M_ME_NB_1:
+		return M_ME_NB_1_SQ_1_MSG_LEN, true
+	case M_ME_TB_1:
+		return M_ME_TB_1_SQ_1_MSG_LEN, true
+	case M_ME_NC_1:
+		return 5, true
+	case M_IT_NA_1:
+		return 5, true
+	case M_PS_NA_1:
+		return M_PS_NA_1_SQ_1_MSG_LEN, true
+	case M_ME_ND_1:
+		return M_ME_ND_1_SQ_1_MSG_LEN, true
+	case M_ME_TE_1:
+		return M_ME_TE_1_SQ_1_MSG_LEN, true
+	case M_BO_TB_1:
+		return M_BO_TB_1_SQ_1_MSG_LEN, true
+	case M_EP_TD_1:
+		return M_EP_TD_1_SQ_1_MSG_LEN, true
+	case M_EP_TE_1:
+		return M_EP_TE_1_SQ_1_MSG_LEN, true
+	case M_EP_TF_1:
+		return M_EP_TF_1_SQ_1_MSG_LEN, true
+	case C_BO_NA_1:
+		return 4, true
+	case M_EI_NA_1, C_IC_NA_1, C_CI_NA_1, P_AC_NA_1, C_RP_NA_1, C_SC_NA_1, C_DC_NA_1, C_RC_NA_1:
+		return 1, true
+	case C_SE_NA_1, C_SE_NB_1:
+		return 3, true
+	case C_SE_NC_1:
+		return 5, true
+	case C_SC_TA_1, C_DC_TA_1, C_RC_TA_1:
+		return 1 + CP56TIME2A_LEN, true
+	case C_SE_TA_1, C_SE_TB_1:
+		return 3 + CP56TIME2A_LEN, true
+	case C_SE_TC_1:
+		return 5 + CP56TIME2A_LEN, true
+	case C_BO_TA_1:
+		return 4 + CP56TIME2A_LEN, true
+	case C_TS_NA_1:
+		return 2, true
+	case C_TS_TA_1:
+		return 2 + CP56TIME2A_LEN, true
+	case P_ME_NA_1, P_ME_NB_1:
+		return 3, true
+	case P_ME_NC_1:
+		return 5, true
+	case C_CS_NA_1:
+		return CP56TIME2A_LEN, true
+	case F_FR_NA_1:
+		return 6, true
+	case F_SR_NA_1:
+		return 7, true
+	case F_SC_NA_1, F_AF_NA_1:
+		return 4, true
+	case F_LS_NA_1:
+		return 5, true
+	case F_SG_NA_1:
+		// 段长度可变，此处为不含段数据的最小长度
+		return 4, true
+	case F_DR_TA_1:
+		return F_DR_TA_1_SQ_1_MSG_LEN, true
+	default:
+		return 0, false
+	}
+}
@@ -1,37 +1,112 @@
 package elements
 
-import (
-	"fmt"
-)
-
-// ParseASDU 解析asdu
+// ParseASDU 按104规约标准参数解析asdu
 func ParseASDU(asdu []byte) (ASDU, error) {
-	if asdu == nil || len(asdu) < 4 {
-		return ASDU{}, fmt.Errorf("asdu[%X]非法", asdu)
-	}
-	dui, err := parseDUI(asdu)
+	return ParseASDUWithParams(asdu, DefaultParams)
+}
+
+// ParseASDUWithParams 按指定参数解析asdu
+//
+// 严格模式下信息体长度必须与可变结构限定词中的信息对象数目完全一致；
+// 宽松模式下忽略多余字节，信息体不足时仅解析完整的信息对象。
+func ParseASDUWithParams(asdu []byte, p Params) (ASDU, error) {
+	dui, body, err := parseHeader(asdu, p)
 	if err != nil {
-		return ASDU{}, fmt.Errorf("解析asdu[%X]的DUI异常: %v", asdu, err)
+		return ASDU{}, err
 	}
 
 	var messageBody BytesConverter
 	switch dui.TypeIdentification {
 	case M_ME_NC_1:
-		var err error
-		messageBody, err = parseM_ME_NC_1(asdu, dui)
-		if err != nil {
-			return ASDU{}, fmt.Errorf("解析asdu[%X]的messageBody异常: %v", asdu, err)
-		}
+		messageBody = parseM_ME_NC_1(body, dui)
+	case M_BO_NA_1:
+		messageBody = parseM_BO_NA_1(body, dui)
+	case M_BO_TB_1:
+		messageBody = parseM_BO_TB_1(body, dui)
 	case M_ME_NA_1:
-		var err error
-		messageBody, err = parseM_ME_NA_1(asdu, dui)
-		if err != nil {
-			return ASDU{}, fmt.Errorf("解析asdu[%X]的messageBody异常: %v", asdu, err)
-		}
+		messageBody = parseM_ME_NA_1(body, dui)
+	case M_ME_NB_1:
+		messageBody = parseM_ME_NB_1(body, dui)
+	case M_ME_TB_1:
+		messageBody = parseM_ME_TB_1(body, dui)
+	case M_PS_NA_1:
+		messageBody = parseM_PS_NA_1(body, dui)
+	case M_ME_ND_1:
+		messageBody = parseM_ME_ND_1(body, dui)
+	case M_ME_TE_1:
+		messageBody = parseM_ME_TE_1(body, dui)
+	case M_IT_NA_1:
+		messageBody = parseM_IT_NA_1(body, dui)
+	case M_EP_TD_1:
+		messageBody = parseM_EP_TD_1(body, dui)
+	case M_EP_TE_1:
+		messageBody = parseM_EP_TE_1(body, dui)
+	case M_EP_TF_1:
+		messageBody = parseM_EP_TF_1(body, dui)
 	case C_IC_NA_1:
-		messageBody = parseC_IC_NA_1(asdu)
-	default:
-		return ASDU{}, fmt.Errorf("未知类型标识[%v]", dui.TypeIdentification)
+		messageBody = parseC_IC_NA_1(body)
+	case C_CI_NA_1:
+		messageBody = parseC_CI_NA_1(body)
+	case C_BO_NA_1:
+		messageBody = parseC_BO_NA_1(body)
+	case C_SC_NA_1:
+		messageBody = parseC_SC_NA_1(body)
+	case C_DC_NA_1:
+		messageBody = parseC_DC_NA_1(body)
+	case C_RC_NA_1:
+		messageBody = parseC_RC_NA_1(body)
+	case C_SE_NA_1:
+		messageBody = parseC_SE_NA_1(body)
+	case C_SE_NB_1:
+		messageBody = parseC_SE_NB_1(body)
+	case C_SE_NC_1:
+		messageBody = parseC_SE_NC_1(body)
+	case C_SC_TA_1:
+		messageBody = parseC_SC_TA_1(body)
+	case C_DC_TA_1:
+		messageBody = parseC_DC_TA_1(body)
+	case C_RC_TA_1:
+		messageBody = parseC_RC_TA_1(body)
+	case C_SE_TA_1:
+		messageBody = parseC_SE_TA_1(body)
+	case C_SE_TB_1:
+		messageBody = parseC_SE_TB_1(body)
+	case C_SE_TC_1:
+		messageBody = parseC_SE_TC_1(body)
+	case C_BO_TA_1:
+		messageBody = parseC_BO_TA_1(body)
+	case M_EI_NA_1:
+		messageBody = parseM_EI_NA_1(body)
+	case C_CS_NA_1:
+		messageBody = parseC_CS_NA_1(body)
+	case P_ME_NA_1:
+		messageBody = parseP_ME_NA_1(body)
+	case P_ME_NB_1:
+		messageBody = parseP_ME_NB_1(body)
+	case P_ME_NC_1:
+		messageBody = parseP_ME_NC_1(body)
+	case P_AC_NA_1:
+		messageBody = parseP_AC_NA_1(body)
+	case C_TS_NA_1:
+		messageBody = parseC_TS_NA_1(body)
+	case C_RP_NA_1:
+		messageBody = parseC_RP_NA_1(body)
+	case C_TS_TA_1:
+		messageBody = parseC_TS_TA_1(body)
+	case F_FR_NA_1:
+		messageBody = parseF_FR_NA_1(body)
+	case F_SR_NA_1:
+		messageBody = parseF_SR_NA_1(body)
+	case F_SC_NA_1:
+		messageBody = parseF_SC_NA_1(body)
+	case F_LS_NA_1:
+		messageBody = parseF_LS_NA_1(body)
+	case F_AF_NA_1:
+		messageBody = parseF_AF_NA_1(body)
+	case F_SG_NA_1:
+		messageBody = parseF_SG_NA_1(body)
+	case F_DR_TA_1:
+		messageBody = parseF_DR_TA_1(body, dui)
 	}
 
 	return ASDU{
@@ -40,45 +115,115 @@ func ParseASDU(asdu []byte) (ASDU, error) {
 	}, nil
 }
 
-func parseDUI(asdu []byte) (DUI, error) {
+// parseHeader 解析数据单元标识符并检查信息体，返回的信息体引用asdu
+func parseHeader(asdu []byte, p Params) (DUI, []byte, error) {
+	if err := p.Validate(); err != nil {
+		return DUI{}, nil, err
+	}
+	p = p.normalize()
+
+	hdr := p.DUISize()
+	if len(asdu) < hdr {
+		return DUI{}, nil, &ParseError{Offset: len(asdu), Data: asdu, Err: ErrTruncated}
+	}
+	dui, err := parseDUI(asdu, p)
+	if err != nil {
+		return DUI{}, nil, &ParseError{Offset: 0, Data: asdu, Err: err}
+	}
+
+	body, err := checkBody(asdu, hdr, &dui, p)
+	if err != nil {
+		return DUI{}, nil, err
+	}
+	return dui, body, nil
+}
+
+// checkBody 检查信息体长度与信息对象数目是否一致，返回截取后的信息体
+func checkBody(asdu []byte, hdr int, dui *DUI, p Params) ([]byte, error) {
+	size, _ := elementSize(dui.TypeIdentification)
+	sq := dui.VSQ().SQ()
+	number := dui.VSQ().Number()
+	body := asdu[hdr:]
+	if dui.TypeIdentification == F_SG_NA_1 {
+		size = segmentSize(body)
+	}
+
+	expected := func(n int) int {
+		if !sq {
+			return n * (IOASize + size)
+		}
+		return IOASize + n*size
+	}
+
+	if number == 0 {
+		return nil, &ParseError{Offset: 1, Data: asdu, Err: ErrLengthMismatch}
+	}
+	if singleObject(dui.TypeIdentification) && (number != 1 || sq) {
+		if !p.Lenient {
+			return nil, &ParseError{Offset: 1, Data: asdu, Err: ErrLengthMismatch}
+		}
+		// 宽松模式：只解析第一个信息对象
+		sq, number = false, 1
+		dui.VariableStructureQualifier = byte(NewVSQ(sq, number))
+	}
+	want := expected(number)
+	switch {
+	case len(body) < want:
+		if !p.Lenient {
+			return nil, &ParseError{Offset: len(asdu), Data: asdu, Err: ErrTruncated}
+		}
+		// 宽松模式：仅保留完整的信息对象
+		for number > 0 && expected(number) > len(body) {
+			number--
+		}
+		if number == 0 {
+			return nil, &ParseError{Offset: len(asdu), Data: asdu, Err: ErrTruncated}
+		}
+		dui.VariableStructureQualifier = byte(NewVSQ(sq, number))
+		want = expected(number)
+	case len(body) > want:
+		if !p.Lenient {
+			return nil, &ParseError{Offset: hdr + want, Data: asdu, Err: ErrLengthMismatch}
+		}
+	}
+	return body[:want], nil
+}
+
+func parseDUI(asdu []byte, p Params) (DUI, error) {
 	var dui DUI
 	var err error
 
 	dui.TypeIdentification, err = parseDUITypeIdentification(asdu[0])
 	if err != nil {
-		return DUI{}, fmt.Errorf("解析asdu的dui的TypeIdentification异常:%v", err)
+		return DUI{}, err
 	}
 
-	switch dui.TypeIdentification {
-	case M_ME_NC_1:
-		dui.VariableStructureQualifier = asdu[1]
-		dui.Cause = asdu[2]
-		dui.CauseExt = asdu[3]
+	dui.VariableStructureQualifier = asdu[1]
+	var originator byte
+	i := 3
+	if p.CauseSize == 2 {
+		originator = asdu[i]
 		dui.CauseExtEnable = true
-		dui.PublicAddressLow = asdu[4]
-		dui.PublicAddressHig = asdu[5]
-		dui.PublicAddressHigEnable = true
-	case M_ME_NA_1:
-		dui.VariableStructureQualifier = asdu[1]
-		dui.Cause = asdu[2]
-		dui.CauseExt = asdu[3]
-		dui.CauseExtEnable = true
-		dui.PublicAddressLow = asdu[4]
-		dui.PublicAddressHig = asdu[5]
+		i++
+	}
+	dui.COT = ParseCOT(asdu[2], originator)
+	dui.PublicAddressLow = asdu[i]
+	if p.CommonAddrSize == 2 {
+		dui.PublicAddressHig = asdu[i+1]
 		dui.PublicAddressHigEnable = true
 	}
 	return dui, nil
 }
 
 func parseDUITypeIdentification(t byte) (byte, error) {
-	switch t {
-	case M_ME_NC_1:
-		return t, nil
-	case M_ME_NA_1:
-		return t, nil
-	case C_IC_NA_1:
-		return t, nil
-	default:
-		return 0x00, fmt.Errorf("未知类型标识[%X]", t)
+	if _, ok := elementSize(t); !ok {
+		return 0x00, &UnknownTypeError{TypeID: t}
 	}
+	return t, nil
+}
+
+// parseIOA 解析3字节信息对象地址
+func parseIOA(b []byte) uint32 {
+	_ = b[2]
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
 }
@@ -1,9 +1,20 @@
 package elements
 
 import (
+	"bytes"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/wangxianzhuo/iec104/internal/frametest"
 )
 
 func Test_Parse(t *testing.T) {
@@ -33,3 +44,625 @@ func Test_Parse(t *testing.T) {
 	fmt.Println(asdu3)
 	// fmt.Println(len(asdu3.MessageBody.(MessageElement_9_SQ_0)))
 }
+
+func Test_ParseMalformed(t *testing.T) {
+	cases := []struct {
+		input string
+		err   error
+	}{
+		// 信息对象数目为2，实际只有1个
+		{"0D0203000100014000000080BF00", ErrTruncated},
+		// 信息对象被截断
+		{"0D01030001000140000000", ErrTruncated},
+		// 信息体末尾有多余字节
+		{"0D0103000100014000000080BF0000", ErrLengthMismatch},
+		// 信息对象数目为0
+		{"0D0003000100", ErrLengthMismatch},
+		// SQ=1，信息对象数目为127，实际只有1个
+		{"09FF030001000140000100", ErrTruncated},
+		{"0D01", ErrTruncated},
+		// 命令只能包含一个信息对象
+		{"6482060001000000001400", ErrLengthMismatch},
+	}
+	for _, c := range cases {
+		input, _ := hex.DecodeString(c.input)
+		_, err := ParseASDU(input)
+		if !errors.Is(err, c.err) {
+			t.Fatalf("asdu[%s]的解析异常[%v]应为[%v]", c.input, err, c.err)
+		}
+	}
+
+	lenient := Params{Lenient: true}
+	input, _ := hex.DecodeString("0D0203000100014000000080BF00024000")
+	asdu, err := ParseASDUWithParams(input, lenient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(asdu.MessageBody.(MessageElement_13_SQ_0)) != 1 {
+		t.Fatalf("宽松模式应只解析完整的信息对象: %v", asdu)
+	}
+
+	short := Params{CauseSize: 1, CommonAddrSize: 1}
+	input, _ = hex.DecodeString("0D0103010140000000803F00")
+	asdu, err = ParseASDUWithParams(input, short)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := asdu.MessageBody.(MessageElement_13_SQ_0)[0].Core.Value; v != 1 {
+		t.Fatalf("值[%v]应为1", v)
+	}
+}
+
+func randomQDS(r *rand.Rand) QDS {
+	return ParseQDS(byte(r.Intn(256)) & 0xF1)
+}
+
+func randomBCR(r *rand.Rand) BCR {
+	return ParseBCR([]byte{byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256))})
+}
+
+func randomInt16(r *rand.Rand) int16 {
+	return int16(r.Intn(1<<16) - 1<<15)
+}
+
+func randomCP24Time2a(r *rand.Rand) CP24Time2a {
+	return CP24Time2a{Millisecond: uint16(r.Intn(60000)), Minute: byte(r.Intn(60)), IV: r.Intn(2) == 1}
+}
+
+func randomCP56Time2a(r *rand.Rand) CP56Time2a {
+	t := NewCP56Time2a(time.Date(2000+r.Intn(100), time.Month(1+r.Intn(12)), 1+r.Intn(28), r.Intn(24), r.Intn(60), r.Intn(60), r.Intn(1000)*int(time.Millisecond), time.UTC))
+	t.IV = r.Intn(2) == 1
+	t.SU = r.Intn(2) == 1
+	return t
+}
+
+func randomCore_38(r *rand.Rand) MessageElementCore_38 {
+	return MessageElementCore_38{SEP: ParseSEP(byte(r.Intn(256))), Elapsed: CP16Time2a(r.Intn(60000)), Time: randomCP56Time2a(r)}
+}
+
+func randomCore_39(r *rand.Rand) MessageElementCore_39 {
+	return MessageElementCore_39{SPE: ParseSPE(byte(r.Intn(256))), QDP: ParseQDP(byte(r.Intn(256))), Elapsed: CP16Time2a(r.Intn(60000)), Time: randomCP56Time2a(r)}
+}
+
+func randomCore_40(r *rand.Rand) MessageElementCore_40 {
+	return MessageElementCore_40{OCI: ParseOCI(byte(r.Intn(256))), QDP: ParseQDP(byte(r.Intn(256))), Elapsed: CP16Time2a(r.Intn(60000)), Time: randomCP56Time2a(r)}
+}
+
+// testTypes 所有支持的类型
+var testTypes = []byte{M_EI_NA_1, C_CS_NA_1, P_ME_NA_1, P_ME_NB_1, P_ME_NC_1, P_AC_NA_1, C_TS_NA_1, C_RP_NA_1, C_TS_TA_1,
+	C_SC_NA_1, C_DC_NA_1, C_RC_NA_1, C_SE_NA_1, C_SE_NB_1, C_SE_NC_1,
+	C_SC_TA_1, C_DC_TA_1, C_RC_TA_1, C_SE_TA_1, C_SE_TB_1, C_SE_TC_1, C_BO_TA_1,
+	F_FR_NA_1, F_SR_NA_1, F_SC_NA_1, F_LS_NA_1, F_AF_NA_1, F_SG_NA_1, F_DR_TA_1, M_PS_NA_1, M_EP_TD_1, M_EP_TE_1, M_EP_TF_1, M_BO_NA_1, M_BO_TB_1, C_BO_NA_1, M_ME_NA_1, M_ME_NB_1, M_ME_TB_1, M_ME_NC_1, M_IT_NA_1, M_ME_ND_1, M_ME_TE_1, C_IC_NA_1, C_CI_NA_1}
+
+// randomASDU 生成指定类型的随机asdu
+func randomASDU(r *rand.Rand, t byte, sq bool) ASDU {
+	number := 1 + r.Intn(20)
+	address := uint32(r.Intn(1 << 24))
+	if sq {
+		address = uint32(r.Intn(1<<24 - number))
+	}
+	dui := DUI{
+		TypeIdentification:     t,
+		COT:                    ParseCOT(byte(r.Intn(256)), byte(r.Intn(256))),
+		CauseExtEnable:         true,
+		PublicAddressLow:       byte(r.Intn(256)),
+		PublicAddressHig:       byte(r.Intn(256)),
+		PublicAddressHigEnable: true,
+	}
+	dui.VariableStructureQualifier = byte(number)
+	if sq {
+		dui.VariableStructureQualifier |= 0x80
+	}
+
+	var body BytesConverter
+	switch {
+	case t == M_ME_NC_1 && sq:
+		e := MessageElement_13_SQ_1{Address: address}
+		for i := 0; i < number; i++ {
+			e.Cores = append(e.Cores, MessageElementCore_13{Value: (r.Float32() - 0.5) * 1e6, QDS: randomQDS(r)})
+		}
+		body = e
+	case t == M_ME_NC_1:
+		var e MessageElement_13_SQ_0
+		for i := 0; i < number; i++ {
+			e = append(e, MessageElement_13_SQ_0_Ele{Address: uint32(r.Intn(1 << 24)), Core: MessageElementCore_13{Value: (r.Float32() - 0.5) * 1e6, QDS: randomQDS(r)}})
+		}
+		body = e
+	case t == M_ME_NA_1 && sq:
+		e := MessageElement_9_SQ_1{Address: address}
+		for i := 0; i < number; i++ {
+			e.Cores = append(e.Cores, MessageElementCore_9{Value: NVA(r.Intn(1<<16) - 1<<15), QDS: randomQDS(r)})
+		}
+		body = e
+	case t == M_ME_NA_1:
+		var e MessageElement_9_SQ_0
+		for i := 0; i < number; i++ {
+			e = append(e, MessageElement_9_SQ_0_Ele{Address: uint32(r.Intn(1 << 24)), Core: MessageElementCore_9{Value: NVA(r.Intn(1<<16) - 1<<15), QDS: randomQDS(r)}})
+		}
+		body = e
+	case t == M_IT_NA_1 && sq:
+		e := MessageElement_15_SQ_1{Address: address}
+		for i := 0; i < number; i++ {
+			e.Cores = append(e.Cores, randomBCR(r))
+		}
+		body = e
+	case t == M_IT_NA_1:
+		var e MessageElement_15_SQ_0
+		for i := 0; i < number; i++ {
+			e = append(e, MessageElement_15_SQ_0_Ele{Address: uint32(r.Intn(1 << 24)), Core: randomBCR(r)})
+		}
+		body = e
+	case t == M_ME_NB_1 && sq:
+		e := MessageElement_11_SQ_1{Address: address}
+		for i := 0; i < number; i++ {
+			e.Cores = append(e.Cores, MessageElementCore_11{Value: randomInt16(r), QDS: randomQDS(r)})
+		}
+		body = e
+	case t == M_ME_NB_1:
+		var e MessageElement_11_SQ_0
+		for i := 0; i < number; i++ {
+			e = append(e, MessageElement_11_SQ_0_Ele{Address: uint32(r.Intn(1 << 24)), Core: MessageElementCore_11{Value: randomInt16(r), QDS: randomQDS(r)}})
+		}
+		body = e
+	case t == M_ME_TB_1 && sq:
+		e := MessageElement_12_SQ_1{Address: address}
+		for i := 0; i < number; i++ {
+			e.Cores = append(e.Cores, MessageElementCore_12{Value: randomInt16(r), QDS: randomQDS(r), Time: randomCP24Time2a(r)})
+		}
+		body = e
+	case t == M_ME_TB_1:
+		var e MessageElement_12_SQ_0
+		for i := 0; i < number; i++ {
+			e = append(e, MessageElement_12_SQ_0_Ele{Address: uint32(r.Intn(1 << 24)), Core: MessageElementCore_12{Value: randomInt16(r), QDS: randomQDS(r), Time: randomCP24Time2a(r)}})
+		}
+		body = e
+	case t == M_ME_ND_1 && sq:
+		e := MessageElement_21_SQ_1{Address: address}
+		for i := 0; i < number; i++ {
+			e.Cores = append(e.Cores, MessageElementCore_21{Value: NVA(randomInt16(r))})
+		}
+		body = e
+	case t == M_ME_ND_1:
+		var e MessageElement_21_SQ_0
+		for i := 0; i < number; i++ {
+			e = append(e, MessageElement_21_SQ_0_Ele{Address: uint32(r.Intn(1 << 24)), Core: MessageElementCore_21{Value: NVA(randomInt16(r))}})
+		}
+		body = e
+	case t == M_ME_TE_1 && sq:
+		e := MessageElement_35_SQ_1{Address: address}
+		for i := 0; i < number; i++ {
+			e.Cores = append(e.Cores, MessageElementCore_35{Value: randomInt16(r), QDS: randomQDS(r), Time: randomCP56Time2a(r)})
+		}
+		body = e
+	case t == M_ME_TE_1:
+		var e MessageElement_35_SQ_0
+		for i := 0; i < number; i++ {
+			e = append(e, MessageElement_35_SQ_0_Ele{Address: uint32(r.Intn(1 << 24)), Core: MessageElementCore_35{Value: randomInt16(r), QDS: randomQDS(r), Time: randomCP56Time2a(r)}})
+		}
+		body = e
+	case t == M_BO_NA_1 && sq:
+		e := MessageElement_7_SQ_1{Address: address}
+		for i := 0; i < number; i++ {
+			e.Cores = append(e.Cores, MessageElementCore_7{Value: BSI(r.Uint32()), QDS: randomQDS(r)})
+		}
+		body = e
+	case t == M_BO_NA_1:
+		var e MessageElement_7_SQ_0
+		for i := 0; i < number; i++ {
+			e = append(e, MessageElement_7_SQ_0_Ele{Address: uint32(r.Intn(1 << 24)), Core: MessageElementCore_7{Value: BSI(r.Uint32()), QDS: randomQDS(r)}})
+		}
+		body = e
+	case t == M_BO_TB_1 && sq:
+		e := MessageElement_33_SQ_1{Address: address}
+		for i := 0; i < number; i++ {
+			e.Cores = append(e.Cores, MessageElementCore_33{Value: BSI(r.Uint32()), QDS: randomQDS(r), Time: randomCP56Time2a(r)})
+		}
+		body = e
+	case t == M_BO_TB_1:
+		var e MessageElement_33_SQ_0
+		for i := 0; i < number; i++ {
+			e = append(e, MessageElement_33_SQ_0_Ele{Address: uint32(r.Intn(1 << 24)), Core: MessageElementCore_33{Value: BSI(r.Uint32()), QDS: randomQDS(r), Time: randomCP56Time2a(r)}})
+		}
+		body = e
+	case t == M_EP_TD_1 && sq:
+		e := MessageElement_38_SQ_1{Address: address}
+		for i := 0; i < number; i++ {
+			e.Cores = append(e.Cores, randomCore_38(r))
+		}
+		body = e
+	case t == M_EP_TD_1:
+		var e MessageElement_38_SQ_0
+		for i := 0; i < number; i++ {
+			e = append(e, MessageElement_38_SQ_0_Ele{Address: uint32(r.Intn(1 << 24)), Core: randomCore_38(r)})
+		}
+		body = e
+	case t == M_EP_TE_1 && sq:
+		e := MessageElement_39_SQ_1{Address: address}
+		for i := 0; i < number; i++ {
+			e.Cores = append(e.Cores, randomCore_39(r))
+		}
+		body = e
+	case t == M_EP_TE_1:
+		var e MessageElement_39_SQ_0
+		for i := 0; i < number; i++ {
+			e = append(e, MessageElement_39_SQ_0_Ele{Address: uint32(r.Intn(1 << 24)), Core: randomCore_39(r)})
+		}
+		body = e
+	case t == M_EP_TF_1 && sq:
+		e := MessageElement_40_SQ_1{Address: address}
+		for i := 0; i < number; i++ {
+			e.Cores = append(e.Cores, randomCore_40(r))
+		}
+		body = e
+	case t == M_EP_TF_1:
+		var e MessageElement_40_SQ_0
+		for i := 0; i < number; i++ {
+			e = append(e, MessageElement_40_SQ_0_Ele{Address: uint32(r.Intn(1 << 24)), Core: randomCore_40(r)})
+		}
+		body = e
+	case t == F_DR_TA_1 && sq:
+		e := MessageElement_126_SQ_1{Address: address}
+		for i := 0; i < number; i++ {
+			e.Cores = append(e.Cores, randomCore_126(r))
+		}
+		body = e
+	case t == F_DR_TA_1:
+		var e MessageElement_126_SQ_0
+		for i := 0; i < number; i++ {
+			e = append(e, MessageElement_126_SQ_0_Ele{Address: uint32(r.Intn(1 << 24)), Core: randomCore_126(r)})
+		}
+		body = e
+	case t == F_FR_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_120{Address: address, NOF: uint16(r.Uint32()), LOF: uint32(r.Intn(1 << 24)), FRQ: byte(r.Intn(256))}
+	case t == F_SR_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_121{Address: address, NOF: uint16(r.Uint32()), NOS: byte(r.Intn(256)), LOS: uint32(r.Intn(1 << 24)), SRQ: byte(r.Intn(256))}
+	case t == F_SC_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_122{Address: address, NOF: uint16(r.Uint32()), NOS: byte(r.Intn(256)), SCQ: ParseFileQualifier(byte(r.Intn(256)))}
+	case t == F_LS_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_123{Address: address, NOF: uint16(r.Uint32()), NOS: byte(r.Intn(256)), LSQ: byte(r.Intn(256)), CHS: byte(r.Intn(256))}
+	case t == F_AF_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_124{Address: address, NOF: uint16(r.Uint32()), NOS: byte(r.Intn(256)), AFQ: ParseFileQualifier(byte(r.Intn(256)))}
+	case t == F_SG_NA_1:
+		dui.VariableStructureQualifier = 1
+		segment := make([]byte, 1+r.Intn(MaxSegmentLen))
+		r.Read(segment)
+		body = MessageElement_125{Address: address, NOF: uint16(r.Uint32()), NOS: byte(r.Intn(256)), Segment: segment}
+	case t == M_PS_NA_1 && sq:
+		e := MessageElement_20_SQ_1{Address: address}
+		for i := 0; i < number; i++ {
+			e.Cores = append(e.Cores, MessageElementCore_20{SCD: SCD{ST: uint16(r.Uint32()), CD: uint16(r.Uint32())}, QDS: randomQDS(r)})
+		}
+		body = e
+	case t == M_PS_NA_1:
+		var e MessageElement_20_SQ_0
+		for i := 0; i < number; i++ {
+			e = append(e, MessageElement_20_SQ_0_Ele{Address: uint32(r.Intn(1 << 24)), Core: MessageElementCore_20{SCD: SCD{ST: uint16(r.Uint32()), CD: uint16(r.Uint32())}, QDS: randomQDS(r)}})
+		}
+		body = e
+	case t == M_EI_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_70{Address: address, COI: ParseCOI(byte(r.Intn(256)))}
+	case t == C_CS_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_103{Address: address, Time: randomCP56Time2a(r)}
+	case t == P_ME_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_110{Address: address, Value: NVA(r.Intn(65536)), QPM: ParseQPM(byte(r.Intn(256)))}
+	case t == P_ME_NB_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_111{Address: address, Value: int16(r.Intn(65536)), QPM: ParseQPM(byte(r.Intn(256)))}
+	case t == P_ME_NC_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_112{Address: address, Value: r.Float32(), QPM: ParseQPM(byte(r.Intn(256)))}
+	case t == P_AC_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_113{Address: address, QPA: byte(r.Intn(256))}
+	case t == C_TS_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_104{Address: address, FBP: uint16(r.Intn(65536))}
+	case t == C_RP_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_105{Address: address, QRP: byte(r.Intn(256))}
+	case t == C_TS_TA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_107{Address: address, TSC: uint16(r.Intn(65536)), Time: randomCP56Time2a(r)}
+	case t == C_SC_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_45{Address: address, SCO: ParseSCO(byte(r.Intn(256)) &^ 0x02)}
+	case t == C_DC_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_46{Address: address, DCO: ParseDCO(byte(r.Intn(256)))}
+	case t == C_RC_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_47{Address: address, RCO: ParseRCO(byte(r.Intn(256)))}
+	case t == C_SE_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_48{Address: address, Value: NVA(r.Intn(65536)), QOS: ParseQOS(byte(r.Intn(256)))}
+	case t == C_SE_NB_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_49{Address: address, Value: int16(r.Intn(65536)), QOS: ParseQOS(byte(r.Intn(256)))}
+	case t == C_SE_NC_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_50{Address: address, Value: r.Float32(), QOS: ParseQOS(byte(r.Intn(256)))}
+	case t == C_SC_TA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_58{Address: address, SCO: ParseSCO(byte(r.Intn(256)) &^ 0x02), Time: randomCP56Time2a(r)}
+	case t == C_DC_TA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_59{Address: address, DCO: ParseDCO(byte(r.Intn(256))), Time: randomCP56Time2a(r)}
+	case t == C_RC_TA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_60{Address: address, RCO: ParseRCO(byte(r.Intn(256))), Time: randomCP56Time2a(r)}
+	case t == C_SE_TA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_61{Address: address, Value: NVA(r.Intn(65536)), QOS: ParseQOS(byte(r.Intn(256))), Time: randomCP56Time2a(r)}
+	case t == C_SE_TB_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_62{Address: address, Value: int16(r.Intn(65536)), QOS: ParseQOS(byte(r.Intn(256))), Time: randomCP56Time2a(r)}
+	case t == C_SE_TC_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_63{Address: address, Value: r.Float32(), QOS: ParseQOS(byte(r.Intn(256))), Time: randomCP56Time2a(r)}
+	case t == C_BO_TA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_64{Address: address, BSI: BSI(r.Uint32()), Time: randomCP56Time2a(r)}
+	case t == C_BO_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_51{Address: address, BSI: BSI(r.Uint32())}
+	case t == C_IC_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_100{Address: address, QOI: byte(r.Intn(256))}
+	case t == C_CI_NA_1:
+		dui.VariableStructureQualifier = 1
+		body = MessageElement_101{Address: address, QCC: byte(r.Intn(256))}
+	}
+	return ASDU{DUI: dui, MessageBody: body}
+}
+
+// Test_RoundTrip 所有支持的类型编码后再解析应得到相同的值
+func Test_RoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, typ := range testTypes {
+		for _, sq := range []bool{false, true} {
+			for i := 0; i < 100; i++ {
+				asdu := randomASDU(r, typ, sq)
+				parsed, err := ParseASDU(asdu.ConvertBytes())
+				if err != nil {
+					t.Fatalf("类型[%d]的asdu[%X]解析异常: %v", typ, asdu.ConvertBytes(), err)
+				}
+				if !reflect.DeepEqual(asdu, parsed) {
+					t.Fatalf("类型[%d]的asdu编解码结果不一致:\n%v\n%v", typ, asdu, parsed)
+				}
+			}
+		}
+	}
+}
+
+// Test_String 所有支持的类型均可显示为带类型助记符的字符串
+func Test_String(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, typ := range testTypes {
+		for _, sq := range []bool{false, true} {
+			s := randomASDU(r, typ, sq).String()
+			if !strings.HasPrefix(s, TypeName(typ)+"(") || strings.Contains(s, "%!") {
+				t.Fatalf("类型[%d]的asdu描述[%s]错误", typ, s)
+			}
+		}
+	}
+
+	input, _ := hex.DecodeString("0D01030001000100000000C03F80")
+	asdu, err := ParseASDU(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, want := asdu.String(), "M_ME_NC_1(13) SQ=0 数目[1] spont(3) 公共地址[1]: IOA[1] Value=1.5 QDS=IV"; s != want {
+		t.Fatalf("asdu描述[%s]应为[%s]", s, want)
+	}
+}
+
+// Test_JSON 所有支持的类型编码为JSON后再还原应得到相同的报文
+func Test_JSON(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, typ := range testTypes {
+		for _, sq := range []bool{false, true} {
+			for i := 0; i < 20; i++ {
+				asdu, err := ParseASDU(randomASDU(r, typ, sq).ConvertBytes())
+				if err != nil {
+					t.Fatal(err)
+				}
+				b, err := json.Marshal(asdu)
+				if err != nil {
+					t.Fatalf("类型[%d]的asdu编码JSON异常: %v", typ, err)
+				}
+				var decoded ASDU
+				if err := json.Unmarshal(b, &decoded); err != nil {
+					t.Fatalf("类型[%d]的JSON[%s]解析异常: %v", typ, b, err)
+				}
+				if !reflect.DeepEqual(asdu, decoded) {
+					t.Fatalf("类型[%d]的asdu经JSON还原后不一致:\n%v\n%v\n%s", typ, asdu, decoded, b)
+				}
+			}
+		}
+	}
+
+	e := MessageElement_13_SQ_0_Ele{Address: 0x4001, Core: MessageElementCore_13{Value: 1.5, QDS: QDS{IV: true}}}
+	b, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"M_ME_NC_1","ioa":16385,"value":1.5,"qds":{"ov":false,"bl":false,"sb":false,"nt":false,"iv":true}}`
+	if string(b) != want {
+		t.Fatalf("信息对象JSON[%s]应为[%s]", b, want)
+	}
+	o, err := UnmarshalObject(b)
+	if err != nil || o != InformationObject(e) {
+		t.Fatalf("信息对象还原为[%v]: %v", o, err)
+	}
+	for _, v := range []float32{float32(math.NaN()), float32(math.Inf(1)), float32(math.Inf(-1))} {
+		e := MessageElement_13_SQ_0_Ele{Address: 1, Core: MessageElementCore_13{Value: v}}
+		b, err := json.Marshal(e)
+		if err != nil {
+			t.Fatalf("浮点数[%v]编码JSON异常: %v", v, err)
+		}
+		o, err := UnmarshalObject(b)
+		if err != nil {
+			t.Fatalf("JSON[%s]解析异常: %v", b, err)
+		}
+		if got := o.(MessageElement_13_SQ_0_Ele).Core.Value; math.Float32bits(got) != math.Float32bits(v) {
+			t.Fatalf("浮点数[%v]经JSON还原为[%v]", v, got)
+		}
+	}
+	var c MessageElement_45
+	if err := json.Unmarshal(b, &c); err == nil {
+		t.Fatal("类型不一致时应返回异常")
+	}
+	if _, err := UnmarshalObject([]byte(`{"type":"M_ME_NC_1","ioa":1,"valu":1}`)); err == nil {
+		t.Fatal("未知字段应返回异常")
+	}
+}
+
+func Test_WriteTo(t *testing.T) {
+	for _, frame := range frametest.Load(t, "testdata/asdu.txt") {
+		asdu, err := ParseASDU(frame)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if _, err := asdu.MessageBody.WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if want := asdu.MessageBody.ConvertBytes(); !bytes.Equal(buf.Bytes(), want) {
+			t.Fatalf("信息体[%T]WriteTo结果[%X]应为[%X]", asdu.MessageBody, buf.Bytes(), want)
+		}
+		if raceEnabled {
+			// 竞态检测下 sync.Pool 随机丢弃缓冲区，不检查内存分配
+			continue
+		}
+		for _, o := range asdu.Objects() {
+			// 类型断言会随机更新断言缓存并分配内存，须在统计前完成
+			w := o.(io.WriterTo)
+			// AllocsPerRun 对平均值向下取整，以单次运行统计100次调用的总分配次数
+			allocs := testing.AllocsPerRun(1, func() {
+				for i := 0; i < 100; i++ {
+					w.WriteTo(io.Discard)
+				}
+			})
+			if allocs != 0 {
+				t.Fatalf("信息对象[%T]WriteTo 100次分配内存%v次", o, allocs)
+			}
+		}
+	}
+}
+
+func Test_COT(t *testing.T) {
+	input, _ := hex.DecodeString("64014703010000000014")
+	asdu, err := ParseASDU(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := COT{Cause: COT_ACTCON, Negative: true, Test: false, Originator: 3}
+	if asdu.DUI.COT != want {
+		t.Fatalf("传送原因[%+v]应为[%+v]", asdu.DUI.COT, want)
+	}
+	if s := asdu.DUI.COT.String(); s != "actcon(7) 否定 源发站地址[3]" {
+		t.Fatalf("传送原因描述[%s]错误", s)
+	}
+	if s := Cause(23).String(); s != "inro3(23)" {
+		t.Fatalf("传送原因描述[%s]错误", s)
+	}
+	if Cause(14).Valid() || !COT_UNKNOWN_IOA.Valid() {
+		t.Fatal("传送原因有效性判断错误")
+	}
+	if b := (COT{Cause: COT_ACT, Test: true}).Byte(); b != 0x86 {
+		t.Fatalf("传送原因编码[%X]应为86", b)
+	}
+}
+
+func Test_Objects(t *testing.T) {
+	// M_ME_NA_1，SQ=1，起始地址4001H，2个信息对象
+	input, _ := hex.DecodeString("098214000100014000FF7F00008090")
+	asdu, err := ParseASDU(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vsq := asdu.DUI.VSQ()
+	if !vsq.SQ() || vsq.Number() != 2 || NewVSQ(true, 2) != vsq {
+		t.Fatalf("可变结构限定词[%X]解析错误", byte(vsq))
+	}
+	objs := asdu.Objects()
+	if len(objs) != 2 {
+		t.Fatalf("信息对象数目[%d]应为2", len(objs))
+	}
+	for i, o := range objs {
+		if o.IOA() != 0x4001+uint32(i) || o.TypeID() != M_ME_NA_1 {
+			t.Fatalf("第%d个信息对象[%+v]地址错误", i, o)
+		}
+	}
+	m := objs[1].(Measurand)
+	if m.Float() != -1 || !m.Quality().IV || !m.Quality().BL {
+		t.Fatalf("信息对象[%+v]的值或品质描述词错误", m)
+	}
+
+	if (ASDU{}).Objects() != nil {
+		t.Fatal("空信息体应返回nil")
+	}
+}
+
+func Test_NVA(t *testing.T) {
+	cases := []struct {
+		value NVA
+		f     float64
+	}{
+		{0, 0},
+		{16384, 0.5},
+		{-32768, -1},
+		{32767, 1 - 1.0/32768},
+	}
+	for _, c := range cases {
+		if c.value.Float() != c.f {
+			t.Fatalf("规一化值[%d]应为[%v]，实际为[%v]", c.value, c.f, c.value.Float())
+		}
+		if NewNVA(c.f) != c.value {
+			t.Fatalf("小数[%v]应转换为规一化值[%d]", c.f, c.value)
+		}
+	}
+	if NewNVA(2) != 32767 || NewNVA(-2) != -32768 {
+		t.Fatal("超出范围时应取边界值")
+	}
+}
+
+func Test_CP56Time2a(t *testing.T) {
+	now := time.Date(2018, 10, 7, 13, 45, 59, 123*int(time.Millisecond), time.Local)
+	c := NewCP56Time2a(now)
+	if c.Weekday != 7 {
+		t.Fatalf("星期日应编码为7: %+v", c)
+	}
+	parsed := ParseCP56Time2a(c.ConvertBytes())
+	if parsed != c || !parsed.Time(time.Local).Equal(now) {
+		t.Fatalf("CP56Time2a[%+v]编解码结果[%+v]不一致", c, parsed)
+	}
+	c24 := ParseCP24Time2a(NewCP24Time2a(now).ConvertBytes())
+	if !c24.Time(now.Add(time.Minute)).Equal(now) {
+		t.Fatalf("CP24Time2a[%+v]时间错误", c24)
+	}
+	// 59分的时标在下一小时的00分接收，应取前一小时
+	c24 = CP24Time2a{Millisecond: 30000, Minute: 59}
+	ref := time.Date(2018, 10, 7, 10, 0, 30, 0, time.Local)
+	want := time.Date(2018, 10, 7, 9, 59, 30, 0, time.Local)
+	if got := c24.Time(ref); !got.Equal(want) {
+		t.Fatalf("CP24Time2a[%+v]以[%v]补全为[%v]，应为[%v]", c24, ref, got, want)
+	}
+}
+
+func randomCore_126(r *rand.Rand) MessageElementCore_126 {
+	return MessageElementCore_126{
+		NOF:  uint16(r.Uint32()),
+		LOF:  uint32(r.Intn(1 << 24)),
+		SOF:  ParseSOF(byte(r.Intn(256))),
+		Time: randomCP56Time2a(r),
+	}
+}
@@ -0,0 +1,205 @@
+package elements
+
+import "io"
+
+// 继电保护设备事件状态，SEP的ES位
+const (
+	ES_INDETERMINATE = 0 // 不确定或中间状态
+	ES_OFF           = 1 // 开
+	ES_ON            = 2 // 合
+	ES_INVALID       = 3 // 不确定
+)
+
+// ProtectionObject 继电保护设备事件信息对象（M_EP_TD_1、M_EP_TE_1、M_EP_TF_1）
+type ProtectionObject interface {
+	InformationObject
+	EventTime() CP56Time2a // 事件发生时间
+	Elapsed() CP16Time2a   // 动作时间、继电器持续时间或继电器动作时间
+}
+
+// SEP 继电保护设备单个事件，《DLT 634.5101-2002》 7.2.6.10
+type SEP struct {
+	ES byte // 事件状态，ES_OFF、ES_ON等
+	EI bool // false(0) = 动作时间有效 | true(1) = 动作时间无效
+	BL bool // false(0) = 未被锁闭 | true(1) = 被锁闭
+	SB bool // false(0) = 未被取代 | true(1) = 被取代
+	NT bool // false(0) = 当前值 | true(1) = 非当前值
+	IV bool // false(0) = 有效 | true(1) = 无效
+}
+
+// AppendTo 将编码结果追加到dst
+func (s SEP) AppendTo(dst []byte) []byte {
+	b := s.ES & 0x03
+	if s.EI {
+		b |= 0x08
+	}
+	if s.BL {
+		b |= 0x10
+	}
+	if s.SB {
+		b |= 0x20
+	}
+	if s.NT {
+		b |= 0x40
+	}
+	if s.IV {
+		b |= 0x80
+	}
+	return append(dst, b)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (s SEP) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, s)
+}
+
+// ParseSEP 解析SEP
+func ParseSEP(b byte) SEP {
+	return SEP{
+		ES: b & 0x03,
+		EI: b&0x08 != 0,
+		BL: b&0x10 != 0,
+		SB: b&0x20 != 0,
+		NT: b&0x40 != 0,
+		IV: b&0x80 != 0,
+	}
+}
+
+// String 如 合(2) EI|IV
+func (s SEP) String() string {
+	es := [...]string{"不确定(0)", "开(1)", "合(2)", "不确定(3)"}[s.ES&0x03]
+	return es + " " + flagString([]string{"EI", "BL", "SB", "NT", "IV"}, s.EI, s.BL, s.SB, s.NT, s.IV)
+}
+
+// QDP 继电保护设备事件的品质描述词，《DLT 634.5101-2002》 7.2.6.4
+type QDP struct {
+	EI bool // false(0) = 动作时间有效 | true(1) = 动作时间无效
+	BL bool // false(0) = 未被锁闭 | true(1) = 被锁闭
+	SB bool // false(0) = 未被取代 | true(1) = 被取代
+	NT bool // false(0) = 当前值 | true(1) = 非当前值
+	IV bool // false(0) = 有效 | true(1) = 无效
+}
+
+// AppendTo 将编码结果追加到dst
+func (q QDP) AppendTo(dst []byte) []byte {
+	var b byte
+	if q.EI {
+		b |= 0x08
+	}
+	if q.BL {
+		b |= 0x10
+	}
+	if q.SB {
+		b |= 0x20
+	}
+	if q.NT {
+		b |= 0x40
+	}
+	if q.IV {
+		b |= 0x80
+	}
+	return append(dst, b)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (q QDP) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, q)
+}
+
+// ParseQDP 解析QDP
+func ParseQDP(b byte) QDP {
+	return QDP{
+		EI: b&0x08 != 0,
+		BL: b&0x10 != 0,
+		SB: b&0x20 != 0,
+		NT: b&0x40 != 0,
+		IV: b&0x80 != 0,
+	}
+}
+
+// String 置位的品质标志，如 EI|IV，均未置位时为-
+func (q QDP) String() string {
+	return flagString([]string{"EI", "BL", "SB", "NT", "IV"}, q.EI, q.BL, q.SB, q.NT, q.IV)
+}
+
+// SPE 继电保护设备启动事件，《DLT 634.5101-2002》 7.2.6.11
+type SPE struct {
+	GS  bool // 总启动
+	SL1 bool // A相保护启动
+	SL2 bool // B相保护启动
+	SL3 bool // C相保护启动
+	SIE bool // 接地电流保护启动
+	SRD bool // 反向保护启动
+}
+
+// AppendTo 将编码结果追加到dst
+func (s SPE) AppendTo(dst []byte) []byte {
+	var b byte
+	for i, bit := range []bool{s.GS, s.SL1, s.SL2, s.SL3, s.SIE, s.SRD} {
+		if bit {
+			b |= 1 << i
+		}
+	}
+	return append(dst, b)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (s SPE) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, s)
+}
+
+// ParseSPE 解析SPE
+func ParseSPE(b byte) SPE {
+	return SPE{
+		GS:  b&0x01 != 0,
+		SL1: b&0x02 != 0,
+		SL2: b&0x04 != 0,
+		SL3: b&0x08 != 0,
+		SIE: b&0x10 != 0,
+		SRD: b&0x20 != 0,
+	}
+}
+
+// String 置位的启动标志，如 GS|SL1
+func (s SPE) String() string {
+	return flagString([]string{"GS", "SL1", "SL2", "SL3", "SIE", "SRD"}, s.GS, s.SL1, s.SL2, s.SL3, s.SIE, s.SRD)
+}
+
+// OCI 继电保护设备输出电路信息，《DLT 634.5101-2002》 7.2.6.12
+type OCI struct {
+	GC  bool // 总命令输出至输出电路
+	CL1 bool // 命令输出至A相输出电路
+	CL2 bool // 命令输出至B相输出电路
+	CL3 bool // 命令输出至C相输出电路
+}
+
+// AppendTo 将编码结果追加到dst
+func (o OCI) AppendTo(dst []byte) []byte {
+	var b byte
+	for i, bit := range []bool{o.GC, o.CL1, o.CL2, o.CL3} {
+		if bit {
+			b |= 1 << i
+		}
+	}
+	return append(dst, b)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (o OCI) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, o)
+}
+
+// ParseOCI 解析OCI
+func ParseOCI(b byte) OCI {
+	return OCI{
+		GC:  b&0x01 != 0,
+		CL1: b&0x02 != 0,
+		CL2: b&0x04 != 0,
+		CL3: b&0x08 != 0,
+	}
+}
+
+// String 置位的输出标志，如 GC|CL1
+func (o OCI) String() string {
+	return flagString([]string{"GC", "CL1", "CL2", "CL3"}, o.GC, o.CL1, o.CL2, o.CL3)
+}
@@ -0,0 +1,197 @@
+package elements
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	CP24TIME2A_LEN = 3
+	CP56TIME2A_LEN = 7
+)
+
+// CP24Time2a 三个八位位组二进制时间，《DLT 634.5101-2002》 7.2.6.19
+type CP24Time2a struct {
+	Millisecond uint16 // 毫秒 0-59999（含秒）
+	Minute      byte   // 分 0-59
+	IV          bool   // false(0) = 有效 | true(1) = 无效
+}
+
+// NewCP24Time2a 由t的分、秒及毫秒创建CP24Time2a
+func NewCP24Time2a(t time.Time) CP24Time2a {
+	return CP24Time2a{
+		Millisecond: uint16(t.Second()*1000 + t.Nanosecond()/int(time.Millisecond)),
+		Minute:      byte(t.Minute()),
+	}
+}
+
+func (c CP24Time2a) ConvertBytes() []byte {
+	return c.AppendTo(make([]byte, 0, CP24TIME2A_LEN))
+}
+
+// AppendTo 将编码结果追加到dst
+func (c CP24Time2a) AppendTo(dst []byte) []byte {
+	minute := c.Minute & 0x3F
+	if c.IV {
+		minute |= 0x80
+	}
+	return append(dst, byte(c.Millisecond), byte(c.Millisecond>>8), minute)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (c CP24Time2a) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, c)
+}
+
+// Time 以ref所在的小时补全时间，ref为接收时间；结果晚于ref时取前一小时
+func (c CP24Time2a) Time(ref time.Time) time.Time {
+	t := time.Date(ref.Year(), ref.Month(), ref.Day(), ref.Hour(), int(c.Minute),
+		int(c.Millisecond/1000), int(c.Millisecond%1000)*int(time.Millisecond), ref.Location())
+	if t.After(ref) {
+		t = t.Add(-time.Hour)
+	}
+	return t
+}
+
+// ParseCP24Time2a 解析CP24Time2a
+func ParseCP24Time2a(b []byte) CP24Time2a {
+	return CP24Time2a{
+		Millisecond: binary.LittleEndian.Uint16(b[0:2]),
+		Minute:      b[2] & 0x3F,
+		IV:          b[2]&0x80 != 0,
+	}
+}
+
+// String 如 04:05.678，时标无效时后缀 IV
+func (c CP24Time2a) String() string {
+	s := fmt.Sprintf("%02d:%02d.%03d", c.Minute, c.Millisecond/1000, c.Millisecond%1000)
+	if c.IV {
+		s += " IV"
+	}
+	return s
+}
+
+// CP56Time2a 七个八位位组二进制时间，《DLT 634.5101-2002》 7.2.6.18
+type CP56Time2a struct {
+	Millisecond uint16 // 毫秒 0-59999（含秒）
+	Minute      byte   // 分 0-59
+	IV          bool   // false(0) = 有效 | true(1) = 无效
+	Hour        byte   // 时 0-23
+	SU          bool   // false(0) = 标准时间 | true(1) = 夏季时间
+	Day         byte   // 日 1-31
+	Weekday     byte   // 星期 1-7，0表示未使用
+	Month       byte   // 月 1-12
+	Year        byte   // 年 0-99
+}
+
+// NewCP56Time2a 由t创建CP56Time2a，年份取后两位
+func NewCP56Time2a(t time.Time) CP56Time2a {
+	weekday := byte(t.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	return CP56Time2a{
+		Millisecond: uint16(t.Second()*1000 + t.Nanosecond()/int(time.Millisecond)),
+		Minute:      byte(t.Minute()),
+		Hour:        byte(t.Hour()),
+		Day:         byte(t.Day()),
+		Weekday:     weekday,
+		Month:       byte(t.Month()),
+		Year:        byte(t.Year() % 100),
+	}
+}
+
+func (c CP56Time2a) ConvertBytes() []byte {
+	return c.AppendTo(make([]byte, 0, CP56TIME2A_LEN))
+}
+
+// AppendTo 将编码结果追加到dst
+func (c CP56Time2a) AppendTo(dst []byte) []byte {
+	minute := c.Minute & 0x3F
+	if c.IV {
+		minute |= 0x80
+	}
+	hour := c.Hour & 0x1F
+	if c.SU {
+		hour |= 0x80
+	}
+	return append(dst,
+		byte(c.Millisecond), byte(c.Millisecond>>8),
+		minute,
+		hour,
+		c.Day&0x1F|c.Weekday<<5,
+		c.Month&0x0F,
+		c.Year&0x7F,
+	)
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (c CP56Time2a) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, c)
+}
+
+// Time 转换为loc时区的时间，年份按2000年后计算
+func (c CP56Time2a) Time(loc *time.Location) time.Time {
+	return time.Date(2000+int(c.Year), time.Month(c.Month), int(c.Day), int(c.Hour), int(c.Minute),
+		int(c.Millisecond/1000), int(c.Millisecond%1000)*int(time.Millisecond), loc)
+}
+
+// ParseCP56Time2a 解析CP56Time2a
+func ParseCP56Time2a(b []byte) CP56Time2a {
+	return CP56Time2a{
+		Millisecond: binary.LittleEndian.Uint16(b[0:2]),
+		Minute:      b[2] & 0x3F,
+		IV:          b[2]&0x80 != 0,
+		Hour:        b[3] & 0x1F,
+		SU:          b[3]&0x80 != 0,
+		Day:         b[4] & 0x1F,
+		Weekday:     b[4] >> 5,
+		Month:       b[5] & 0x0F,
+		Year:        b[6] & 0x7F,
+	}
+}
+
+// String 如 2024-01-02 03:04:05.678，时标无效或为夏季时间时后缀 IV、SU
+func (c CP56Time2a) String() string {
+	s := fmt.Sprintf("%04d-%02d-%02d %02d:%02d:%02d.%03d", 2000+int(c.Year), c.Month, c.Day,
+		c.Hour, c.Minute, c.Millisecond/1000, c.Millisecond%1000)
+	if c.IV {
+		s += " IV"
+	}
+	if c.SU {
+		s += " SU"
+	}
+	return s
+}
+
+const CP16TIME2A_LEN = 2
+
+// CP16Time2a 二个八位位组二进制时间，《DLT 634.5101-2002》 7.2.6.20，单位为毫秒，0-59999
+type CP16Time2a uint16
+
+// Duration 转换为时间间隔
+func (c CP16Time2a) Duration() time.Duration {
+	return time.Duration(c) * time.Millisecond
+}
+
+// String 如 120ms
+func (c CP16Time2a) String() string {
+	return fmt.Sprintf("%dms", uint16(c))
+}
+
+// AppendTo 将编码结果追加到dst
+func (c CP16Time2a) AppendTo(dst []byte) []byte {
+	return append(dst, byte(c), byte(c>>8))
+}
+
+// WriteTo 将编码结果写入w，实现 io.WriterTo
+func (c CP16Time2a) WriteTo(w io.Writer) (int64, error) {
+	return WriteTo(w, c)
+}
+
+// ParseCP16Time2a 解析CP16Time2a
+func ParseCP16Time2a(b []byte) CP16Time2a {
+	return CP16Time2a(binary.LittleEndian.Uint16(b[0:2]))
+}
@@ -0,0 +1,6 @@
+//go:build !race
+
+package iec104
+
+// raceEnabled 是否启用竞态检测
+const raceEnabled = false
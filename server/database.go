@@ -0,0 +1,188 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+// Deadband 测量值参数，由主站以P_ME_NA_1、P_ME_NB_1、P_ME_NC_1装载，以P_AC_NA_1激活
+type Deadband struct {
+	Threshold float64 // 门限值，与上次上送值之差不小于门限值时突发上送，0表示任何变化都上送
+	Smoothing float64 // 平滑系数(0, 1)，当前值 = 旧值 + 平滑系数 × (采样值 - 旧值)，0表示不平滑
+	Low       float64 // 下限，低于下限时不受门限值限制，总是突发上送
+	High      float64 // 上限，高于上限时不受门限值限制，总是突发上送；Low与High都为0表示不设限值
+	Active    bool    // 参数是否运行，未运行时任何变化都突发上送
+}
+
+// Point 从站测量值点
+type Point struct {
+	Address  uint32       // 信息对象地址
+	TypeID   byte         // 上送类型：M_ME_NA_1、M_ME_NB_1或M_ME_NC_1
+	Value    float64      // 当前值，规一化值为[-1, 1)区间内的小数
+	QDS      elements.QDS // 品质描述词
+	Deadband Deadband     // 测量值参数
+
+	sent float64 // 上次上送的值
+}
+
+// object 以点的上送类型创建信息对象
+func (p *Point) object() elements.InformationObject {
+	switch p.TypeID {
+	case elements.M_ME_NA_1:
+		return elements.MessageElement_9_SQ_0_Ele{
+			Address: p.Address,
+			Core:    elements.MessageElementCore_9{Value: elements.NewNVA(p.Value), QDS: p.QDS},
+		}
+	case elements.M_ME_NB_1:
+		v := math.Max(math.MinInt16, math.Min(math.MaxInt16, math.Round(p.Value)))
+		return elements.MessageElement_11_SQ_0_Ele{
+			Address: p.Address,
+			Core:    elements.MessageElementCore_11{Value: int16(v), QDS: p.QDS},
+		}
+	default:
+		return elements.MessageElement_13_SQ_0_Ele{
+			Address: p.Address,
+			Core:    elements.MessageElementCore_13{Value: float32(p.Value), QDS: p.QDS},
+		}
+	}
+}
+
+// report 判断更新后的点是否需要突发上送
+func (p *Point) report(qdsChanged bool) bool {
+	d := p.Deadband
+	if !d.Active || qdsChanged {
+		return p.Value != p.sent || qdsChanged
+	}
+	if (d.Low != 0 || d.High != 0) && (p.Value < d.Low || p.Value > d.High) {
+		return p.Value != p.sent
+	}
+	diff := math.Abs(p.Value - p.sent)
+	return diff != 0 && diff >= d.Threshold
+}
+
+// Database 从站点数据库，可并发使用
+type Database struct {
+	mux    sync.RWMutex
+	points map[uint32]*Point
+}
+
+// NewDatabase 创建点数据库，信息对象地址不能重复
+func NewDatabase(points []Point) (*Database, error) {
+	db := &Database{points: make(map[uint32]*Point, len(points))}
+	for _, p := range points {
+		switch p.TypeID {
+		case elements.M_ME_NA_1, elements.M_ME_NB_1, elements.M_ME_NC_1:
+		default:
+			return nil, fmt.Errorf("点[%X]的上送类型[%d]不支持", p.Address, p.TypeID)
+		}
+		if _, ok := db.points[p.Address]; ok {
+			return nil, fmt.Errorf("点[%X]重复", p.Address)
+		}
+		p := p
+		p.sent = p.Value
+		db.points[p.Address] = &p
+	}
+	return db, nil
+}
+
+// Point 获取点的当前状态
+func (db *Database) Point(address uint32) (Point, bool) {
+	db.mux.RLock()
+	defer db.mux.RUnlock()
+	p, ok := db.points[address]
+	if !ok {
+		return Point{}, false
+	}
+	return *p, true
+}
+
+// Objects 所有点的信息对象，按类型标识及信息对象地址排序，用于响应总召唤
+func (db *Database) Objects() []elements.InformationObject {
+	db.mux.Lock()
+	defer db.mux.Unlock()
+	objs := make([]elements.InformationObject, 0, len(db.points))
+	for _, p := range db.points {
+		p.sent = p.Value
+		objs = append(objs, p.object())
+	}
+	sort.Slice(objs, func(i, j int) bool {
+		if objs[i].TypeID() != objs[j].TypeID() {
+			return objs[i].TypeID() < objs[j].TypeID()
+		}
+		return objs[i].IOA() < objs[j].IOA()
+	})
+	return objs
+}
+
+// Update 更新点的采样值，按测量值参数平滑后判断是否需要突发上送，需要时返回上送的信息对象
+func (db *Database) Update(address uint32, value float64, qds elements.QDS) (elements.InformationObject, bool, error) {
+	db.mux.Lock()
+	defer db.mux.Unlock()
+	p, ok := db.points[address]
+	if !ok {
+		return nil, false, fmt.Errorf("%w: %X", ErrUnknownPoint, address)
+	}
+	if d := p.Deadband; d.Active && d.Smoothing > 0 && d.Smoothing < 1 {
+		value = p.Value + d.Smoothing*(value-p.Value)
+	}
+	qdsChanged := p.QDS != qds
+	p.Value, p.QDS = value, qds
+	if !p.report(qdsChanged) {
+		return nil, false, nil
+	}
+	p.sent = p.Value
+	return p.object(), true, nil
+}
+
+// LoadParameter 装载测量值参数，QPM.POP为参数未运行时停止参数运行，否则参数立即运行
+func (db *Database) LoadParameter(param elements.Parameter) error {
+	db.mux.Lock()
+	defer db.mux.Unlock()
+	p, ok := db.points[param.IOA()]
+	if !ok {
+		return fmt.Errorf("%w: %X", ErrUnknownPoint, param.IOA())
+	}
+	qpm := param.Qualifier()
+	d := p.Deadband
+	switch qpm.KPA {
+	case elements.QPM_THRESHOLD:
+		d.Threshold = param.Float()
+	case elements.QPM_SMOOTHING:
+		d.Smoothing = param.Float()
+	case elements.QPM_LOW_LIMIT:
+		d.Low = param.Float()
+	case elements.QPM_HIGH_LIMIT:
+		d.High = param.Float()
+	default:
+		return fmt.Errorf("%w: 参数类别[%d]", ErrUnsupported, qpm.KPA)
+	}
+	d.Active = !qpm.POP
+	p.Deadband = d
+	return nil
+}
+
+// Activate 激活或停止激活测量值参数，qpa为 elements.QPA_GENERAL 时作用于所有点
+func (db *Database) Activate(address uint32, qpa byte, activate bool) error {
+	db.mux.Lock()
+	defer db.mux.Unlock()
+	switch qpa {
+	case elements.QPA_GENERAL:
+		for _, p := range db.points {
+			p.Deadband.Active = activate
+		}
+		return nil
+	case elements.QPA_OBJECT:
+		p, ok := db.points[address]
+		if !ok {
+			return fmt.Errorf("%w: %X", ErrUnknownPoint, address)
+		}
+		p.Deadband.Active = activate
+		return nil
+	default:
+		return fmt.Errorf("%w: 参数激活限定词[%d]", ErrUnsupported, qpa)
+	}
+}
@@ -0,0 +1,12 @@
+package server
+
+import "errors"
+
+var (
+	// ErrClosed 从站已关闭
+	ErrClosed = errors.New("从站已关闭")
+	// ErrUnknownPoint 点数据库中不存在该信息对象地址
+	ErrUnknownPoint = errors.New("未知的信息对象地址")
+	// ErrUnsupported 不支持的限定词或参数类别
+	ErrUnsupported = errors.New("不支持的限定词")
+)
@@ -0,0 +1,241 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+// sectionLen 文件传输时每节的最大长度
+const sectionLen = 0xFFFF
+
+// File 可供主站召唤的文件
+type File struct {
+	Address uint32    // 信息对象地址
+	Name    uint16    // 文件名称（NOF）
+	Size    int       // 文件长度，不超过16M
+	Time    time.Time // 文件的创建时间
+}
+
+// FileProvider 提供文件目录及文件内容
+type FileProvider interface {
+	// Files 返回文件目录
+	Files() ([]File, error)
+	// ReadFile 读取信息对象地址为address的文件内容
+	ReadFile(address uint32) ([]byte, error)
+}
+
+// DirProvider 以目录中的普通文件作为传输文件，按文件名排序，信息对象地址依次为 BaseAddress+序号
+type DirProvider struct {
+	Dir         string
+	BaseAddress uint32
+}
+
+// Files 返回目录中的普通文件，文件名称均为 elements.NOF_TRANSPARENT
+func (d DirProvider) Files() ([]File, error) {
+	entries, err := os.ReadDir(d.Dir)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	var files []File
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{
+			Address: d.BaseAddress + uint32(len(files)),
+			Name:    elements.NOF_TRANSPARENT,
+			Size:    int(info.Size()),
+			Time:    info.ModTime(),
+		})
+	}
+	return files, nil
+}
+
+// ReadFile 读取信息对象地址为address的文件内容
+func (d DirProvider) ReadFile(address uint32) ([]byte, error) {
+	entries, err := os.ReadDir(d.Dir)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	index := d.BaseAddress
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		if index == address {
+			return os.ReadFile(filepath.Join(d.Dir, entry.Name()))
+		}
+		index++
+	}
+	return nil, fmt.Errorf("%w: 文件[%X]", ErrUnknownPoint, address)
+}
+
+// transfer 一个连接上正在传输的文件
+type transfer struct {
+	address uint32
+	name    uint16
+	data    []byte
+	nos     byte // 当前节，从1开始
+}
+
+// section 第nos节的内容
+func (t *transfer) section(nos byte) []byte {
+	start := (int(nos) - 1) * sectionLen
+	if nos == 0 || start >= len(t.data) {
+		return nil
+	}
+	return t.data[start:min(start+sectionLen, len(t.data))]
+}
+
+// file 处理主站的文件传输报文，返回需依次发送的响应
+//
+// 与 Server.handle 相同，公共地址既不是本站地址也不是广播地址时以未知公共地址否定确认。
+func (ss *session) file(req elements.ASDU) []elements.ASDU {
+	s := ss.srv
+	if !s.addressed(req) {
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_CA, true)}
+	}
+	if s.cfg.Files == nil {
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_IOA, true)}
+	}
+	switch cmd := req.MessageBody.(type) {
+	case elements.MessageElement_122:
+		if req.DUI.COT.Cause == elements.COT_REQ {
+			return s.directory(req)
+		}
+		return ss.callFile(req, cmd)
+	case elements.MessageElement_124:
+		return ss.fileAck(req, cmd)
+	default:
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_TYPE, true)}
+	}
+}
+
+// directory 以请求上送文件目录，最后一个文件置LFD
+func (s *Server) directory(req elements.ASDU) []elements.ASDU {
+	files, err := s.cfg.Files.Files()
+	if err != nil {
+		s.Log.Errorf("读取文件目录异常: %v", err)
+		return []elements.ASDU{mirror(req, elements.COT_REQ, true)}
+	}
+	objs := make([]elements.InformationObject, len(files))
+	for i, f := range files {
+		objs[i] = elements.MessageElement_126_SQ_0_Ele{
+			Address: f.Address,
+			Core: elements.MessageElementCore_126{
+				NOF:  f.Name,
+				LOF:  uint32(f.Size),
+				SOF:  elements.SOF{LFD: i == len(files)-1},
+				Time: elements.NewCP56Time2a(f.Time.In(s.cfg.Location)),
+			},
+		}
+	}
+	if len(objs) == 0 {
+		// 空目录以否定的请求响应
+		return []elements.ASDU{mirror(req, elements.COT_REQ, true)}
+	}
+	asdus, err := elements.Pack(s.dui(elements.F_DR_TA_1, elements.COT_REQ), objs, s.cfg.Params)
+	if err != nil {
+		s.Log.Errorf("文件目录打包异常: %v", err)
+	}
+	return asdus
+}
+
+// callFile 处理选择文件、召唤文件、召唤节及停止激活文件
+func (ss *session) callFile(req elements.ASDU, cmd elements.MessageElement_122) []elements.ASDU {
+	s := ss.srv
+	ca := s.cfg.CommonAddress
+	switch cmd.SCQ.Code {
+	case elements.SCQ_SELECT_FILE:
+		data, err := s.cfg.Files.ReadFile(cmd.Address)
+		if err != nil || len(data) > 0xFFFFFF {
+			s.Log.Warnf("选择文件[%X]异常: %v", cmd.Address, err)
+			ss.xfer = nil
+			return []elements.ASDU{elements.NewASDUF_FR_NA_1(elements.COT_FILE, ca, cmd.Address, cmd.NOF, 0, elements.FRQ_NEGATIVE)}
+		}
+		ss.xfer = &transfer{address: cmd.Address, name: cmd.NOF, data: data}
+		return []elements.ASDU{elements.NewASDUF_FR_NA_1(elements.COT_FILE, ca, cmd.Address, cmd.NOF, uint32(len(data)), 0)}
+	case elements.SCQ_CALL_FILE:
+		t := ss.selected(cmd.Address)
+		if t == nil {
+			return []elements.ASDU{mirror(req, elements.COT_FILE, true)}
+		}
+		t.nos = 1
+		return []elements.ASDU{ss.sectionReady(t)}
+	case elements.SCQ_CALL_SECTION:
+		t := ss.selected(cmd.Address)
+		if t == nil || cmd.NOS != t.nos {
+			return []elements.ASDU{mirror(req, elements.COT_FILE, true)}
+		}
+		return ss.segments(t)
+	case elements.SCQ_DEACTIVATE_FILE:
+		ss.xfer = nil
+		return nil
+	default:
+		return []elements.ASDU{mirror(req, elements.COT_FILE, true)}
+	}
+}
+
+// fileAck 处理节或文件的认可：节认可后准备下一节，所有节传输完成后发送最后的节；文件认可或否定认可后结束传输
+func (ss *session) fileAck(req elements.ASDU, cmd elements.MessageElement_124) []elements.ASDU {
+	t := ss.selected(cmd.Address)
+	if t == nil {
+		return []elements.ASDU{mirror(req, elements.COT_FILE, true)}
+	}
+	switch cmd.AFQ.Code {
+	case elements.AFQ_SECTION_ACK:
+		t.nos++
+		if t.section(t.nos) != nil {
+			return []elements.ASDU{ss.sectionReady(t)}
+		}
+		chs := elements.Checksum(t.data)
+		return []elements.ASDU{elements.NewASDUF_LS_NA_1(elements.COT_FILE, ss.srv.cfg.CommonAddress, t.address, t.name, 0, elements.LSQ_FILE, chs)}
+	case elements.AFQ_SECTION_NACK, elements.AFQ_FILE_NACK:
+		ss.srv.Log.Warnf("主站否定认可文件[%X]节[%d]: 原因[%d]", t.address, cmd.NOS, cmd.AFQ.Err)
+		ss.xfer = nil
+		return nil
+	default:
+		ss.xfer = nil
+		return nil
+	}
+}
+
+// selected 返回已选择的地址为address的文件，未选择时返回nil
+func (ss *session) selected(address uint32) *transfer {
+	if ss.xfer == nil || ss.xfer.address != address {
+		return nil
+	}
+	return ss.xfer
+}
+
+// sectionReady 当前节准备就绪
+func (ss *session) sectionReady(t *transfer) elements.ASDU {
+	los := uint32(len(t.section(t.nos)))
+	return elements.NewASDUF_SR_NA_1(elements.COT_FILE, ss.srv.cfg.CommonAddress, t.address, t.name, t.nos, los, 0)
+}
+
+// segments 将当前节分段发送，最后发送带节校验和的最后的段
+func (ss *session) segments(t *transfer) []elements.ASDU {
+	ca := ss.srv.cfg.CommonAddress
+	section := t.section(t.nos)
+	room := elements.MaxASDULen - ss.srv.cfg.Params.DUISize() - elements.IOASize - 4
+	var resps []elements.ASDU
+	for rest := section; len(rest) > 0; {
+		n := min(room, len(rest))
+		resps = append(resps, elements.NewASDUF_SG_NA_1(elements.COT_FILE, ca, t.address, t.name, t.nos, rest[:n]))
+		rest = rest[n:]
+	}
+	chs := elements.Checksum(section)
+	return append(resps, elements.NewASDUF_LS_NA_1(elements.COT_FILE, ca, t.address, t.name, t.nos, elements.LSQ_SECTION, chs))
+}
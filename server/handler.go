@@ -0,0 +1,249 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+// handle 处理主站发送的ASDU，返回需依次发送的响应
+//
+// 公共地址既不是本站地址也不是广播地址时以未知公共地址否定确认，不支持的类型以未知类型标识否定确认。
+func (s *Server) handle(req elements.ASDU) []elements.ASDU {
+	dui := req.DUI
+	if !s.addressed(req) {
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_CA, true)}
+	}
+
+	switch dui.TypeIdentification {
+	case elements.C_IC_NA_1:
+		return s.interrogation(req)
+	case elements.C_CS_NA_1:
+		return s.clockSync(req)
+	case elements.P_ME_NA_1, elements.P_ME_NB_1, elements.P_ME_NC_1:
+		return s.loadParameter(req)
+	case elements.P_AC_NA_1:
+		return s.activateParameter(req)
+	case elements.C_TS_NA_1, elements.C_TS_TA_1:
+		return s.testCommand(req)
+	case elements.C_RP_NA_1:
+		return s.resetProcess(req)
+	case elements.C_SC_NA_1, elements.C_DC_NA_1, elements.C_RC_NA_1,
+		elements.C_SE_NA_1, elements.C_SE_NB_1, elements.C_SE_NC_1, elements.C_BO_NA_1,
+		elements.C_SC_TA_1, elements.C_DC_TA_1, elements.C_RC_TA_1,
+		elements.C_SE_TA_1, elements.C_SE_TB_1, elements.C_SE_TC_1, elements.C_BO_TA_1:
+		return s.command(req)
+	default:
+		s.Log.Warnf("不支持的ASDU类型[%d]", dui.TypeIdentification)
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_TYPE, true)}
+	}
+}
+
+// addressed 返回req的公共地址是否为本站地址或广播地址
+func (s *Server) addressed(req elements.ASDU) bool {
+	broadcast := uint16(0xFFFF)
+	if !req.DUI.PublicAddressHigEnable {
+		broadcast = 0xFF
+	}
+	ca := req.DUI.CommonAddress()
+	return ca == s.cfg.CommonAddress || ca == broadcast
+}
+
+// mirror 以镜像方式响应命令，仅修改传送原因
+func mirror(req elements.ASDU, cause elements.Cause, negative bool) elements.ASDU {
+	req.DUI.COT = elements.COT{Cause: cause, Negative: negative, Originator: req.DUI.COT.Originator}
+	return req
+}
+
+// interrogation 响应站召唤：激活确认、以响应站召唤上送点数据库中的所有点、激活终止
+func (s *Server) interrogation(req elements.ASDU) []elements.ASDU {
+	cmd, ok := req.MessageBody.(elements.MessageElement_100)
+	if !ok || req.DUI.COT.Cause != elements.COT_ACT {
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_COT, true)}
+	}
+	if cmd.QOI != elements.QOI_GLOBAL_CALL {
+		// 未配置分组，组召唤无数据
+		return []elements.ASDU{mirror(req, elements.COT_ACTCON, false), mirror(req, elements.COT_ACTTERM, false)}
+	}
+
+	resps := []elements.ASDU{mirror(req, elements.COT_ACTCON, false)}
+	if s.cfg.Points != nil {
+		objs := s.cfg.Points.Objects()
+		for i := 0; i < len(objs); {
+			j := i + 1
+			for j < len(objs) && objs[j].TypeID() == objs[i].TypeID() {
+				j++
+			}
+			asdus, err := elements.Pack(s.dui(objs[i].TypeID(), elements.COT_INTRGEN), objs[i:j], s.cfg.Params)
+			if err != nil {
+				s.Log.Errorf("站召唤数据打包异常: %v", err)
+			}
+			resps = append(resps, asdus...)
+			i = j
+		}
+	}
+	return append(resps, mirror(req, elements.COT_ACTTERM, false))
+}
+
+// clockSync 确认时钟同步命令，从站不修改本地时钟
+func (s *Server) clockSync(req elements.ASDU) []elements.ASDU {
+	if req.DUI.COT.Cause != elements.COT_ACT {
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_COT, true)}
+	}
+	return []elements.ASDU{mirror(req, elements.COT_ACTCON, false)}
+}
+
+// loadParameter 装载测量值参数，装载失败时否定确认
+func (s *Server) loadParameter(req elements.ASDU) []elements.ASDU {
+	p, ok := req.MessageBody.(elements.Parameter)
+	if !ok || req.DUI.COT.Cause != elements.COT_ACT {
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_COT, true)}
+	}
+	load := s.cfg.OnParameter
+	if load == nil && s.cfg.Points != nil {
+		load = s.cfg.Points.LoadParameter
+	}
+	if load == nil {
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_IOA, true)}
+	}
+	if err := load(p); err != nil {
+		s.Log.Warnf("装载测量值参数[%X]异常: %v", p.IOA(), err)
+		return []elements.ASDU{mirror(req, elements.COT_ACTCON, true)}
+	}
+	return []elements.ASDU{mirror(req, elements.COT_ACTCON, false)}
+}
+
+// activateParameter 激活或停止激活参数，失败时否定确认
+func (s *Server) activateParameter(req elements.ASDU) []elements.ASDU {
+	cmd, ok := req.MessageBody.(elements.MessageElement_113)
+	cause := req.DUI.COT.Cause
+	if !ok || cause != elements.COT_ACT && cause != elements.COT_DEACT {
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_COT, true)}
+	}
+	con := elements.COT_ACTCON
+	if cause == elements.COT_DEACT {
+		con = elements.COT_DEACTCON
+	}
+	activate := s.cfg.OnActivate
+	if activate == nil && s.cfg.Points != nil {
+		activate = s.cfg.Points.Activate
+	}
+	if activate == nil {
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_IOA, true)}
+	}
+	if err := activate(cmd.Address, cmd.QPA, cause == elements.COT_ACT); err != nil {
+		s.Log.Warnf("激活参数[%X]异常: %v", cmd.Address, err)
+		return []elements.ASDU{mirror(req, con, true)}
+	}
+	return []elements.ASDU{mirror(req, con, false)}
+}
+
+// testCommand 确认测试命令，C_TS_NA_1的测试图像错误时否定确认
+func (s *Server) testCommand(req elements.ASDU) []elements.ASDU {
+	if req.DUI.COT.Cause != elements.COT_ACT {
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_COT, true)}
+	}
+	if cmd, ok := req.MessageBody.(elements.MessageElement_104); ok && cmd.FBP != elements.FBP_TEST {
+		return []elements.ASDU{mirror(req, elements.COT_ACTCON, true)}
+	}
+	return []elements.ASDU{mirror(req, elements.COT_ACTCON, false)}
+}
+
+// resetProcess 确认复位进程命令，进程总复位后发送初始化结束（远方复位）
+func (s *Server) resetProcess(req elements.ASDU) []elements.ASDU {
+	cmd, ok := req.MessageBody.(elements.MessageElement_105)
+	if !ok || req.DUI.COT.Cause != elements.COT_ACT {
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_COT, true)}
+	}
+	var err error
+	switch {
+	case s.cfg.OnReset != nil:
+		err = s.cfg.OnReset(cmd.QRP)
+	case cmd.QRP != elements.QRP_GENERAL && cmd.QRP != elements.QRP_EVENTS:
+		err = fmt.Errorf("%w: 复位进程命令限定词[%d]", ErrUnsupported, cmd.QRP)
+	}
+	if err != nil {
+		s.Log.Warnf("复位进程异常: %v", err)
+		return []elements.ASDU{mirror(req, elements.COT_ACTCON, true)}
+	}
+
+	resps := []elements.ASDU{mirror(req, elements.COT_ACTCON, false)}
+	if cmd.QRP == elements.QRP_GENERAL {
+		dui := s.dui(elements.M_EI_NA_1, elements.COT_INIT)
+		dui.VariableStructureQualifier = byte(elements.NewVSQ(false, 1))
+		resps = append(resps, elements.ASDU{
+			DUI:         dui,
+			MessageBody: elements.MessageElement_70{COI: elements.COI{Cause: elements.COI_REMOTE_RESET}},
+		})
+	}
+	return resps
+}
+
+// command 执行控制命令，成功时激活确认，非选择命令执行后发送激活终止；停止激活（撤销选择）时直接确认
+//
+// 配置 Config.MaxCommandAge 时，时标无效或与当前时间相差超过该值（过旧或超前）的带时标命令不执行，否定确认。
+func (s *Server) command(req elements.ASDU) []elements.ASDU {
+	objs := req.Objects()
+	switch {
+	case len(objs) != 1:
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_COT, true)}
+	case req.DUI.COT.Cause == elements.COT_DEACT:
+		return []elements.ASDU{mirror(req, elements.COT_DEACTCON, false)}
+	case req.DUI.COT.Cause != elements.COT_ACT:
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_COT, true)}
+	}
+	cmd := objs[0]
+
+	if tc, ok := cmd.(elements.TimedCommand); ok && s.cfg.MaxCommandAge > 0 {
+		t := tc.CommandTime()
+		age := time.Since(t.Time(s.cfg.Location))
+		if t.IV || age > s.cfg.MaxCommandAge || age < -s.cfg.MaxCommandAge {
+			s.Log.Warnf("命令[%d]信息对象地址[%X]时标偏差(%v)过大或时标无效，拒绝执行", req.DUI.TypeIdentification, cmd.IOA(), age)
+			return []elements.ASDU{mirror(req, elements.COT_ACTCON, true)}
+		}
+	}
+	if s.cfg.OnCommand == nil {
+		return []elements.ASDU{mirror(req, elements.COT_UNKNOWN_IOA, true)}
+	}
+	if err := s.cfg.OnCommand(cmd); err != nil {
+		s.Log.Warnf("命令[%d]信息对象地址[%X]执行异常: %v", req.DUI.TypeIdentification, cmd.IOA(), err)
+		return []elements.ASDU{mirror(req, elements.COT_ACTCON, true)}
+	}
+	if isSelect(cmd) {
+		return []elements.ASDU{mirror(req, elements.COT_ACTCON, false)}
+	}
+	return []elements.ASDU{mirror(req, elements.COT_ACTCON, false), mirror(req, elements.COT_ACTTERM, false)}
+}
+
+// isSelect 命令是否为选择（S/E=1）
+func isSelect(cmd elements.InformationObject) bool {
+	switch e := cmd.(type) {
+	case elements.MessageElement_45:
+		return e.SCO.QOC.Select
+	case elements.MessageElement_58:
+		return e.SCO.QOC.Select
+	case elements.MessageElement_46:
+		return e.DCO.QOC.Select
+	case elements.MessageElement_59:
+		return e.DCO.QOC.Select
+	case elements.MessageElement_47:
+		return e.RCO.QOC.Select
+	case elements.MessageElement_60:
+		return e.RCO.QOC.Select
+	case elements.MessageElement_48:
+		return e.QOS.Select
+	case elements.MessageElement_61:
+		return e.QOS.Select
+	case elements.MessageElement_49:
+		return e.QOS.Select
+	case elements.MessageElement_62:
+		return e.QOS.Select
+	case elements.MessageElement_50:
+		return e.QOS.Select
+	case elements.MessageElement_63:
+		return e.QOS.Select
+	default:
+		return false
+	}
+}
@@ -0,0 +1,225 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/wangxianzhuo/iec104/logger"
+	"github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+const (
+	defaultConnectDeadline = 5 * time.Minute
+	defaultTimeout         = 15 * time.Second
+	defaultK               = 12
+)
+
+// Config 从站配置
+type Config struct {
+	Address         string          // 监听地址，如 :2404
+	CommonAddress   uint16          // 应用服务数据单元公共地址，默认为1
+	Params          elements.Params // ASDU编解码参数，默认为104规约标准参数
+	ConnectDeadline time.Duration   // 连接无数据超时时间，默认5分钟
+	Timeout         time.Duration   // 发送及等待主站确认的超时时间（t1），默认15秒
+	K               int             // 未被主站确认的I帧最大数目（k），默认12
+	Log             logger.Logger   // 日志，为nil时不输出日志
+	Points          *Database       // 点数据库，为nil时总召唤无数据
+	Location        *time.Location  // 时标所在时区，默认为time.Local
+	MaxCommandAge   time.Duration   // 带时标命令的最大时标偏差，时标早于或晚于当前时间超过该值或时标无效时否定确认，0表示不检查
+
+	// OnParameter 装载测量值参数时调用，返回异常时否定确认；为nil时装载到 Points
+	OnParameter func(p elements.Parameter) error
+	// OnActivate 激活或停止激活参数时调用，返回异常时否定确认；为nil时修改 Points 中参数的运行状态
+	OnActivate func(address uint32, qpa byte, activate bool) error
+	// OnCommand 执行控制命令（单命令、双命令、步调节命令、设定值命令、32比特串命令及其带时标的类型）时调用，
+	// 返回异常时否定确认；为nil时以未知信息对象地址否定确认
+	OnCommand func(cmd elements.InformationObject) error
+	// OnReset 收到复位进程命令时调用，返回异常时否定确认；为nil时直接确认
+	OnReset func(qrp byte) error
+	// Files 可供主站召唤的文件，为nil时文件传输以未知信息对象地址否定确认
+	Files FileProvider
+}
+
+func (cfg Config) withDefaults() Config {
+	if cfg.CommonAddress == 0 {
+		cfg.CommonAddress = 0x01
+	}
+	if cfg.ConnectDeadline <= 0 {
+		cfg.ConnectDeadline = defaultConnectDeadline
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.K <= 0 {
+		cfg.K = defaultK
+	}
+	if cfg.Location == nil {
+		cfg.Location = time.Local
+	}
+	cfg.Log = logger.OrNop(cfg.Log)
+	return cfg
+}
+
+// Server IEC104从站，可同时服务多个主站连接
+type Server struct {
+	cfg Config
+	l   net.Listener
+	Log logger.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mux      sync.Mutex // 保护sessions
+	sessions map[*session]struct{}
+
+	closeOnce sync.Once
+}
+
+// Listen 监听 Config.Address，调用 Serve 后开始接受连接
+func Listen(cfg Config) (*Server, error) {
+	cfg = cfg.withDefaults()
+	if err := cfg.Params.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.K > 0x7FFF {
+		return nil, fmt.Errorf("未确认I帧最大数目[%d]超过32767", cfg.K)
+	}
+	l, err := net.Listen("tcp", cfg.Address)
+	if err != nil {
+		return nil, fmt.Errorf("监听[%s]异常: %v", cfg.Address, err)
+	}
+	s := &Server{
+		cfg:      cfg,
+		l:        l,
+		Log:      cfg.Log,
+		sessions: make(map[*session]struct{}),
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	return s, nil
+}
+
+// Addr 监听地址
+func (s *Server) Addr() net.Addr {
+	return s.l.Addr()
+}
+
+// Serve 接受主站连接并阻塞，直到ctx结束或调用Close
+//
+// 由Close结束时返回nil，由ctx结束时返回ctx.Err()。
+func (s *Server) Serve(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, func() {
+		s.Close()
+	})
+	defer stop()
+
+	s.wg.Add(1)
+	defer s.wg.Done()
+	s.Log.Infof("IEC104从站启动，监听[%v]", s.l.Addr())
+	for {
+		conn, err := s.l.Accept()
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("接受连接异常: %w", err)
+		}
+		ss := newSession(s, conn)
+		s.mux.Lock()
+		s.sessions[ss] = struct{}{}
+		if s.ctx.Err() != nil {
+			// Close已关闭其他连接
+			conn.Close()
+		}
+		s.mux.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			err := ss.serve()
+			if err != nil && !errors.Is(err, net.ErrClosed) {
+				s.Log.Warnf("主站[%v]连接异常: %v", conn.RemoteAddr(), err)
+			}
+			s.mux.Lock()
+			delete(s.sessions, ss)
+			s.mux.Unlock()
+			conn.Close()
+			s.Log.Infof("主站[%v]连接断开", conn.RemoteAddr())
+		}()
+	}
+}
+
+// Close 停止监听并关闭所有连接，等待所有协程退出，可重复调用
+func (s *Server) Close() error {
+	s.closeOnce.Do(func() {
+		s.cancel()
+		s.l.Close()
+		s.mux.Lock()
+		for ss := range s.sessions {
+			ss.conn.Close()
+		}
+		s.mux.Unlock()
+		s.wg.Wait()
+		s.Log.Infof("IEC104从站停止")
+	})
+	return nil
+}
+
+// Update 更新点数据库中的采样值，超过测量值参数规定的死区时向所有已启动传输的主站突发上送
+//
+// 调用 Close 后返回 ErrClosed，不再更新点数据库。
+func (s *Server) Update(address uint32, value float64, qds elements.QDS) error {
+	if s.ctx.Err() != nil {
+		return ErrClosed
+	}
+	if s.cfg.Points == nil {
+		return fmt.Errorf("%w: %X", ErrUnknownPoint, address)
+	}
+	obj, report, err := s.cfg.Points.Update(address, value, qds)
+	if err != nil || !report {
+		return err
+	}
+	asdus, err := elements.Pack(s.dui(obj.TypeID(), elements.COT_SPONT), []elements.InformationObject{obj}, s.cfg.Params)
+	if err != nil {
+		return err
+	}
+	s.broadcast(asdus)
+	return nil
+}
+
+// broadcast 向所有已启动传输的主站发送asdus
+//
+// 发送时不持有s.mux，避免阻塞的连接影响接受新连接及Close。
+func (s *Server) broadcast(asdus []elements.ASDU) {
+	s.mux.Lock()
+	sessions := make([]*session, 0, len(s.sessions))
+	for ss := range s.sessions {
+		sessions = append(sessions, ss)
+	}
+	s.mux.Unlock()
+	for _, ss := range sessions {
+		for _, asdu := range asdus {
+			err := ss.sendI(asdu)
+			if err != nil {
+				s.Log.Warnf("向主站[%v]突发上送异常: %v", ss.conn.RemoteAddr(), err)
+				break
+			}
+		}
+	}
+}
+
+// dui 以本站公共地址创建数据单元标识符
+func (s *Server) dui(typeID byte, cause elements.Cause) elements.DUI {
+	return elements.DUI{
+		TypeIdentification:     typeID,
+		COT:                    elements.COT{Cause: cause},
+		CauseExtEnable:         true,
+		PublicAddressLow:       byte(s.cfg.CommonAddress),
+		PublicAddressHig:       byte(s.cfg.CommonAddress >> 8),
+		PublicAddressHigEnable: true,
+	}
+}
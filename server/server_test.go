@@ -0,0 +1,416 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wangxianzhuo/iec104"
+	"github.com/wangxianzhuo/iec104/client"
+	"github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+// serve 启动从站，测试结束时关闭
+func serve(t *testing.T, cfg Config) *Server {
+	t.Helper()
+	cfg.Address = "127.0.0.1:0"
+	s, err := Listen(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	served := make(chan error, 1)
+	go func() {
+		served <- s.Serve(context.Background())
+	}()
+	t.Cleanup(func() {
+		s.Close()
+		if err := <-served; err != nil {
+			t.Error(err)
+		}
+	})
+	return s
+}
+
+// dial 连接从站并等待数据传输启动，测试结束时关闭
+func dial(t *testing.T, s *Server, cfg client.Config) *client.Client {
+	t.Helper()
+	cfg.Address = s.Addr().String()
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 500 * time.Millisecond
+	}
+	c, err := client.Dial(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Run(context.Background())
+	}()
+	t.Cleanup(func() {
+		c.Close()
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+	})
+
+	// 启动传输前从站忽略I帧，重试直到应答；公共地址错误时从站否定确认，同样表示已启动
+	ctx := context.Background()
+	deadline := time.Now().Add(5 * time.Second)
+	for err := c.SyncClock(ctx); err != nil && !errors.Is(err, client.ErrNegativeConfirm); err = c.SyncClock(ctx) {
+		if !errors.Is(err, client.ErrNotRunning) && !errors.Is(err, client.ErrTimeout) || time.Now().After(deadline) {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return c
+}
+
+// receive 等待主站输出一帧数据
+func receive(t *testing.T, output <-chan map[string]float32) map[string]float32 {
+	t.Helper()
+	select {
+	case data := <-output:
+		return data
+	case <-time.After(5 * time.Second):
+		t.Fatal("等待数据超时")
+		return nil
+	}
+}
+
+func Test_interrogation(t *testing.T) {
+	db, err := NewDatabase([]Point{
+		{Address: 0x4001, TypeID: elements.M_ME_NC_1, Value: 10},
+		{Address: 0x4002, TypeID: elements.M_ME_NA_1, Value: 0.25},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := serve(t, Config{Points: db})
+	output := make(chan map[string]float32, 16)
+	dial(t, s, client.Config{Output: output})
+
+	// 总召唤响应按类型分帧
+	if data := receive(t, output); data["4002"] != 0.25 {
+		t.Fatalf("站召唤数据[%v]错误", data)
+	}
+	if data := receive(t, output); data["4001"] != 10 {
+		t.Fatalf("站召唤数据[%v]错误", data)
+	}
+
+	// 值不变时不上送
+	if err := s.Update(0x4001, 10, elements.QDS{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Update(0x4001, 10.5, elements.QDS{}); err != nil {
+		t.Fatal(err)
+	}
+	if data := receive(t, output); data["4001"] != 10.5 {
+		t.Fatalf("突发上送数据[%v]错误", data)
+	}
+	if err := s.Update(0x4003, 1, elements.QDS{}); !errors.Is(err, ErrUnknownPoint) {
+		t.Fatalf("未知点更新应返回异常: %v", err)
+	}
+}
+
+func Test_closeWhileBroadcasting(t *testing.T) {
+	db, err := NewDatabase([]Point{{Address: 0x4001, TypeID: elements.M_ME_NC_1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := serve(t, Config{Points: db, Timeout: 200 * time.Millisecond})
+
+	// 主站启动传输后不再读取数据
+	conn, err := net.Dial("tcp", s.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	startdt, _ := iec104.BuildAPDU(iec104.UFrame{STARTDT_ACT: true}, nil)
+	if _, err := startdt.WriteTo(conn); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := iec104.ReadFrame(conn); err != nil {
+		t.Fatal(err)
+	}
+
+	stop := make(chan struct{})
+	updated := make(chan struct{})
+	go func() {
+		defer close(updated)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			s.Update(0x4001, float64(i), elements.QDS{})
+		}
+	}()
+	time.Sleep(500 * time.Millisecond)
+	closed := make(chan struct{})
+	go func() {
+		s.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("突发上送阻塞时关闭从站超时")
+	}
+	close(stop)
+	<-updated
+	if err := s.Update(0x4001, 1, elements.QDS{}); !errors.Is(err, ErrClosed) {
+		t.Fatalf("关闭后更新应返回ErrClosed: %v", err)
+	}
+}
+
+func Test_flowControl(t *testing.T) {
+	db, err := NewDatabase([]Point{{Address: 0x4001, TypeID: elements.M_ME_NC_1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := serve(t, Config{Points: db})
+
+	conn, err := net.Dial("tcp", s.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	write := func(ctr interface{}) {
+		t.Helper()
+		apdu, _ := iec104.BuildAPDU(ctr, nil)
+		if _, err := apdu.WriteTo(conn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// read 读取n个I帧，返回最后一个I帧的发送序号
+	read := func(n int) int16 {
+		t.Helper()
+		var send int16
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		for i := 0; i < n; i++ {
+			frame, err := iec104.ReadFrame(conn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			apdu, err := iec104.ParseAPDU(frame)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, ok := apdu.CtrFrame.(iec104.IFrame)
+			if !ok {
+				t.Fatalf("第%d帧[%v]应为I帧", i, apdu)
+			}
+			send = f.Send
+		}
+		return send
+	}
+	write(iec104.UFrame{STARTDT_ACT: true})
+	if _, err := iec104.ReadFrame(conn); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i <= 20; i++ {
+		if err := s.Update(0x4001, float64(i), elements.QDS{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 未确认的I帧达到k=12个后停止发送
+	if send := read(12); send != 11 {
+		t.Fatalf("第12个I帧的发送序号[%d]应为11", send)
+	}
+	conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	if frame, err := iec104.ReadFrame(conn); err == nil {
+		t.Fatalf("未确认时不应继续发送[%X]", frame)
+	}
+	write(iec104.SFrame{Recv: 12})
+	if send := read(8); send != 19 {
+		t.Fatalf("最后一个I帧的发送序号[%d]应为19", send)
+	}
+}
+
+func Test_commands(t *testing.T) {
+	s := serve(t, Config{})
+	events := make(chan client.Event, 1)
+	c := dial(t, s, client.Config{Events: events})
+
+	ctx := context.Background()
+	if err := c.TestCommand(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.TestCommandWithTime(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ResetProcess(ctx, 9); !errors.Is(err, client.ErrNegativeConfirm) {
+		t.Fatalf("不支持的限定词应否定确认: %v", err)
+	}
+	if err := c.ResetProcess(ctx, elements.QRP_GENERAL); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case e := <-events:
+		if r, ok := e.(*client.StationRestartEvent); !ok || r.COI.Cause != elements.COI_REMOTE_RESET {
+			t.Fatalf("复位后事件[%+v]错误", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("等待初始化结束超时")
+	}
+}
+
+func Test_timeTaggedCommands(t *testing.T) {
+	var mux sync.Mutex
+	var executed []elements.InformationObject
+	s := serve(t, Config{
+		Location:      time.UTC,
+		MaxCommandAge: 10 * time.Second,
+		OnCommand: func(cmd elements.InformationObject) error {
+			mux.Lock()
+			defer mux.Unlock()
+			executed = append(executed, cmd)
+			return nil
+		},
+	})
+
+	ctx := context.Background()
+	c := dial(t, s, client.Config{TimeTaggedCommands: true, Location: time.UTC})
+	if err := c.SingleCommand(ctx, 0x6001, elements.SCO{SCS: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetpointFloat(ctx, 0x6201, 1.5, elements.QOS{}); err != nil {
+		t.Fatal(err)
+	}
+	mux.Lock()
+	if len(executed) != 2 || executed[0].TypeID() != elements.C_SC_TA_1 || executed[1].TypeID() != elements.C_SE_TC_1 {
+		t.Fatalf("执行的命令[%v]错误", executed)
+	}
+	mux.Unlock()
+
+	// 主站时钟比从站慢1小时，时标过旧
+	late := dial(t, s, client.Config{TimeTaggedCommands: true, Location: time.FixedZone("", -3600)})
+	err := late.DoubleCommand(ctx, 0x6101, elements.DCO{DCS: elements.DCS_ON})
+	if !errors.Is(err, client.ErrNegativeConfirm) {
+		t.Fatalf("时标过旧的命令应否定确认: %v", err)
+	}
+	// 主站时钟比从站快1小时，时标超前
+	early := dial(t, s, client.Config{TimeTaggedCommands: true, Location: time.FixedZone("", 3600)})
+	err = early.DoubleCommand(ctx, 0x6101, elements.DCO{DCS: elements.DCS_ON})
+	if !errors.Is(err, client.ErrNegativeConfirm) {
+		t.Fatalf("时标超前的命令应否定确认: %v", err)
+	}
+	// 不带时标的命令不检查
+	untagged := dial(t, s, client.Config{})
+	if err := untagged.DoubleCommand(ctx, 0x6101, elements.DCO{DCS: elements.DCS_ON}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_parameters(t *testing.T) {
+	db, err := NewDatabase([]Point{
+		{Address: 0x4001, TypeID: elements.M_ME_NC_1, Value: 10},
+		{Address: 0x4002, TypeID: elements.M_ME_NA_1, Value: 0.25},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := serve(t, Config{Points: db})
+	output := make(chan map[string]float32, 16)
+	c := dial(t, s, client.Config{Output: output})
+	receive(t, output)
+	receive(t, output)
+
+	ctx := context.Background()
+	threshold := elements.MessageElement_112{
+		Address: 0x4001,
+		Value:   0.5,
+		QPM:     elements.QPM{KPA: elements.QPM_THRESHOLD, POP: true},
+	}
+	if err := c.LoadParameter(ctx, threshold); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := db.Point(0x4001); p.Deadband.Threshold != 0.5 || p.Deadband.Active {
+		t.Fatalf("装载后参数[%+v]错误", p.Deadband)
+	}
+	threshold.Address = 0x4003
+	if err := c.LoadParameter(ctx, threshold); !errors.Is(err, client.ErrNegativeConfirm) {
+		t.Fatalf("未知点装载参数应否定确认: %v", err)
+	}
+	if err := c.ActivateParameter(ctx, 0x4001, elements.QPA_OBJECT, true); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := db.Point(0x4001); !p.Deadband.Active {
+		t.Fatalf("激活后参数[%+v]未运行", p.Deadband)
+	}
+
+	// 变化量小于门限值时不上送
+	if err := s.Update(0x4001, 10.2, elements.QDS{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Update(0x4001, 10.6, elements.QDS{}); err != nil {
+		t.Fatal(err)
+	}
+	if data := receive(t, output); data["4001"] != 10.6 {
+		t.Fatalf("突发上送数据[%v]错误", data)
+	}
+
+	if err := c.ActivateParameter(ctx, 0, elements.QPA_GENERAL, false); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := db.Point(0x4001); p.Deadband.Active {
+		t.Fatalf("停止激活后参数[%+v]仍在运行", p.Deadband)
+	}
+}
+
+func Test_files(t *testing.T) {
+	dir := t.TempDir()
+	big := make([]byte, 2*sectionLen+1000)
+	for i := range big {
+		big[i] = byte(i * 7)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.dat"), []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b.dat"), big, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s := serve(t, Config{Files: DirProvider{Dir: dir, BaseAddress: 0x100}})
+	c := dial(t, s, client.Config{})
+
+	ctx := context.Background()
+	infos, err := c.Directory(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 2 || infos[0].Address != 0x100 || infos[0].Length != 5 ||
+		infos[1].Address != 0x101 || infos[1].Length != len(big) || !infos[1].Status.LFD {
+		t.Fatalf("目录错误: %+v", infos)
+	}
+
+	data, err := c.ReadFile(ctx, 0x101, elements.NOF_TRANSPARENT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, big) {
+		t.Fatalf("文件内容错误: 长度%d", len(data))
+	}
+	if _, err := c.ReadFile(ctx, 0x102, elements.NOF_TRANSPARENT); !errors.Is(err, client.ErrNegativeConfirm) {
+		t.Fatalf("不存在的文件应否定确认: %v", err)
+	}
+
+	// 公共地址错误时以未知公共地址否定确认
+	other := dial(t, s, client.Config{CommonAddress: 2})
+	for name, op := range map[string]func() error{
+		"召唤目录": func() error { _, err := other.Directory(ctx); return err },
+		"读文件":  func() error { _, err := other.ReadFile(ctx, 0x100, elements.NOF_TRANSPARENT); return err },
+	} {
+		var cerr *client.CommandError
+		if err := op(); !errors.As(err, &cerr) || !cerr.COT.Negative || cerr.COT.Cause != elements.COT_UNKNOWN_CA {
+			t.Fatalf("公共地址错误时%s应以未知公共地址否定确认: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/wangxianzhuo/iec104"
+	"github.com/wangxianzhuo/iec104/logger"
+	"github.com/wangxianzhuo/iec104/msg-elements"
+)
+
+// maxQueue 每个连接等待主站确认而排队的最大I帧数目
+const maxQueue = 1024
+
+// session 一个主站连接
+type session struct {
+	srv  *Server
+	conn net.Conn
+
+	mux     sync.Mutex // 保护以下字段，同时作为写锁
+	wbuf    []byte
+	vs      int16           // 发送序号
+	vr      int16           // 接收序号
+	ack     int16           // 主站已确认的发送序号
+	queue   []elements.ASDU // 未确认的I帧达到 Config.K 个时排队等待发送的ASDU
+	t1      *time.Timer     // 等待主站确认超时后关闭连接
+	started bool            // 主站已启动数据传输（STARTDT）
+
+	xfer *transfer // 正在传输的文件，仅由serve所在协程访问
+}
+
+func newSession(s *Server, conn net.Conn) *session {
+	return &session{srv: s, conn: conn}
+}
+
+// serve 处理主站报文，直到连接关闭
+func (ss *session) serve() error {
+	log := ss.srv.Log
+	log.Infof("主站[%v]已连接", ss.conn.RemoteAddr())
+	defer func() {
+		ss.mux.Lock()
+		ss.started, ss.queue = false, nil
+		if ss.t1 != nil {
+			ss.t1.Stop()
+		}
+		ss.mux.Unlock()
+	}()
+	reader := bufio.NewReader(ss.conn)
+	for {
+		ss.conn.SetReadDeadline(time.Now().Add(ss.srv.cfg.ConnectDeadline))
+		frame, err := iec104.ReadFrame(reader)
+		if err != nil {
+			if ss.srv.ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("socket读操作异常: %w", err)
+		}
+
+		if logger.DebugEnabled(log) {
+			log.Debugf("收到原始数据: [% X]", frame)
+		}
+		apdu, err := iec104.ParseAPDUWithParams(frame, ss.srv.cfg.Params)
+		if err != nil {
+			log.Warnf("解析APDU异常: %v", err)
+			continue
+		}
+
+		switch f := apdu.CtrFrame.(type) {
+		case iec104.UFrame:
+			err = ss.uFrame(f)
+		case iec104.SFrame:
+			if logger.DebugEnabled(log) {
+				log.Debugf("接收S帧: [%X]", frame)
+			}
+			err = ss.acked(f.Recv)
+		case iec104.IFrame:
+			err = ss.iFrame(f, apdu.ASDU)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// uFrame 确认U帧命令
+func (ss *session) uFrame(f iec104.UFrame) error {
+	var resp iec104.UFrame
+	switch {
+	case f.STARTDT_ACT:
+		resp.STARTDT_CON = true
+	case f.STOPDT_ACT:
+		resp.STOPDT_CON = true
+	case f.TESTFR_ACT:
+		resp.TESTFR_CON = true
+	default:
+		return nil
+	}
+	ss.mux.Lock()
+	defer ss.mux.Unlock()
+	ss.started = f.STARTDT_ACT || ss.started && !f.STOPDT_ACT
+	if !ss.started {
+		ss.queue = nil
+	}
+	apdu, _ := iec104.BuildAPDU(resp, nil)
+	return ss.write(apdu)
+}
+
+// iFrame 处理主站的I帧，依次发送响应；无响应时以S帧确认
+func (ss *session) iFrame(f iec104.IFrame, asdu elements.ASDU) error {
+	if err := ss.acked(f.Recv); err != nil {
+		return err
+	}
+	ss.mux.Lock()
+	ss.vr = (f.Send + 1) & 0x7FFF
+	started := ss.started
+	ss.mux.Unlock()
+	if !started {
+		ss.srv.Log.Warnf("主站[%v]未启动数据传输，忽略I帧", ss.conn.RemoteAddr())
+		return nil
+	}
+
+	var resps []elements.ASDU
+	switch asdu.DUI.TypeIdentification {
+	case elements.F_SC_NA_1, elements.F_AF_NA_1:
+		resps = ss.file(asdu)
+	default:
+		resps = ss.srv.handle(asdu)
+	}
+	for _, resp := range resps {
+		if err := ss.sendI(resp); err != nil {
+			return err
+		}
+	}
+	if len(resps) > 0 {
+		return nil
+	}
+	ss.mux.Lock()
+	defer ss.mux.Unlock()
+	apdu, _ := iec104.BuildAPDU(iec104.SFrame{Recv: ss.vr}, nil)
+	return ss.write(apdu)
+}
+
+// sendI 以I帧发送asdu，发送序号自增，未启动数据传输时丢弃
+//
+// 未确认的I帧达到 Config.K 个时排队，收到主站确认后继续发送，不阻塞调用方。
+func (ss *session) sendI(asdu elements.ASDU) error {
+	ss.mux.Lock()
+	defer ss.mux.Unlock()
+	if !ss.started {
+		return nil
+	}
+	if len(ss.queue) >= maxQueue {
+		return fmt.Errorf("主站长时间未确认，发送队列已满")
+	}
+	asdu.DUI = ss.srv.cfg.Params.Apply(asdu.DUI)
+	if _, err := iec104.BuildAPDU(iec104.IFrame{}, &asdu); err != nil {
+		return fmt.Errorf("I帧创建异常: %w", err)
+	}
+	ss.queue = append(ss.queue, asdu)
+	return ss.flush()
+}
+
+// unacked 未被主站确认的I帧数目，调用方持有ss.mux
+func (ss *session) unacked() int {
+	return int((ss.vs - ss.ack) & 0x7FFF)
+}
+
+// flush 在发送窗口内发送排队的ASDU，调用方持有ss.mux
+func (ss *session) flush() error {
+	for len(ss.queue) > 0 && ss.started && ss.unacked() < ss.srv.cfg.K {
+		apdu, _ := iec104.BuildAPDU(iec104.IFrame{Send: ss.vs, Recv: ss.vr}, &ss.queue[0])
+		ss.queue[0] = elements.ASDU{}
+		ss.queue = ss.queue[1:]
+		if err := ss.write(apdu); err != nil {
+			return err
+		}
+		if ss.unacked() == 0 {
+			ss.armT1()
+		}
+		ss.vs = (ss.vs + 1) & 0x7FFF
+	}
+	if len(ss.queue) == 0 {
+		ss.queue = nil
+	}
+	return nil
+}
+
+// acked 处理主站对发送序号recv之前的I帧的确认，继续发送排队的ASDU
+func (ss *session) acked(recv int16) error {
+	ss.mux.Lock()
+	defer ss.mux.Unlock()
+	if n := int((recv - ss.ack) & 0x7FFF); n > ss.unacked() {
+		return fmt.Errorf("主站确认的发送序号[%d]超出范围[%d, %d]", recv, ss.ack, ss.vs)
+	} else if n == 0 {
+		return nil
+	}
+	ss.ack = recv
+	if ss.unacked() == 0 {
+		ss.t1.Stop()
+	} else {
+		ss.armT1()
+	}
+	return ss.flush()
+}
+
+// armT1 重新开始等待主站确认的计时，调用方持有ss.mux
+func (ss *session) armT1() {
+	if ss.t1 == nil {
+		ss.t1 = time.AfterFunc(ss.srv.cfg.Timeout, func() {
+			ss.srv.Log.Warnf("主站[%v]确认I帧超时，关闭连接", ss.conn.RemoteAddr())
+			ss.conn.Close()
+		})
+		return
+	}
+	ss.t1.Reset(ss.srv.cfg.Timeout)
+}
+
+// write 编码并发送apdu，调用方持有ss.mux
+//
+// 超过 Config.Timeout 未发送完成时关闭连接，部分发送的报文无法恢复。
+func (ss *session) write(apdu iec104.APDU) error {
+	ss.wbuf = apdu.AppendTo(ss.wbuf[:0])
+	if logger.DebugEnabled(ss.srv.Log) {
+		ss.srv.Log.Debugf("发送: [% X]", ss.wbuf)
+	}
+	ss.conn.SetWriteDeadline(time.Now().Add(ss.srv.cfg.Timeout))
+	_, err := ss.conn.Write(ss.wbuf)
+	if err != nil {
+		ss.conn.Close()
+		return fmt.Errorf("socket写操作异常: %w", err)
+	}
+	return nil
+}